@@ -3,12 +3,14 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/Layr-Labs/hourglass-monorepo/ponos/pkg/performer/server"
 	"go.uber.org/zap"
 
 	appcfg "github.com/liquid-ip/performer/internal/config"
+	appwatcher "github.com/liquid-ip/performer/internal/watcher"
 	appworker "github.com/liquid-ip/performer/internal/worker"
 )
 
@@ -25,24 +27,56 @@ func main() {
 		"rpc", cfg.RpcURL,
 		"erc721", cfg.Erc721PatentRegistryAddress,
 		"ipfs", cfg.IpfsGateway,
+		"ipfsGateways", cfg.IpfsGateways,
+		"ipfsMode", cfg.IpfsMode,
+		"quorum", fmt.Sprintf("%d/%d", cfg.QuorumK, cfg.QuorumN),
 		"schema", cfg.SchemaURI,
+		"policy", cfg.PolicyURI,
+		"mode", cfg.Mode,
 		"port", cfg.Port,
 	)
 
-	w, err := appworker.NewVerifierWorker(l, cfg)
+	store := appcfg.NewStore(cfg)
+
+	w, err := appworker.NewVerifierWorker(l, store)
 	if err != nil {
 		l.Sugar().Fatalw("failed to init worker", "error", err)
 	}
-	pp, err := server.NewPonosPerformerWithRpcServer(&server.PonosPerformerConfig{
-		Port:    cfg.Port,
-		Timeout: 5 * time.Second,
-	}, w, l)
-	if err != nil {
-		panic(fmt.Errorf("failed to create performer: %w", err))
-	}
 
 	ctx := context.Background()
-	if err := pp.Start(ctx); err != nil {
-		l.Sugar().Fatalw("performer exited", "error", err)
+
+	go func() {
+		if err := store.Watch(ctx, os.Getenv("PERFORMER_CONFIG")); err != nil && err != context.Canceled {
+			l.Sugar().Warnw("config watch exited", "error", err)
+		}
+	}()
+
+	if cfg.Mode == "push" || cfg.Mode == "both" {
+		wt, err := appwatcher.NewWatcher(l, store, w, cfg.TaskTimeout)
+		if err != nil {
+			l.Sugar().Fatalw("failed to init watcher", "error", err)
+		}
+		go func() {
+			if err := wt.Run(ctx); err != nil {
+				l.Sugar().Errorw("watcher exited", "error", err)
+			}
+		}()
+	}
+
+	if cfg.Mode == "pull" || cfg.Mode == "both" {
+		pp, err := server.NewPonosPerformerWithRpcServer(&server.PonosPerformerConfig{
+			Port:    cfg.Port,
+			Timeout: 5 * time.Second,
+		}, w, l)
+		if err != nil {
+			panic(fmt.Errorf("failed to create performer: %w", err))
+		}
+		if err := pp.Start(ctx); err != nil {
+			l.Sugar().Fatalw("performer exited", "error", err)
+		}
+		return
 	}
+
+	// push-only mode: block forever, the watcher goroutine drives everything.
+	select {}
 }
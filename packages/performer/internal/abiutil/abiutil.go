@@ -25,6 +25,18 @@ var (
 	}
 )
 
+// Failure status codes. These occupy a separate numeric space from the
+// business statuses in jsonval.StatusFromJSON (0-3) so on-chain consumers
+// can tell a rejected-by-policy result apart from one that simply never
+// reached validation.
+const (
+	StatusMissingKeys     uint8 = 10
+	StatusFetchFailed     uint8 = 11
+	StatusSchemaInvalid   uint8 = 12
+	StatusPolicyViolation uint8 = 13
+	StatusNoQuorum        uint8 = 14
+)
+
 func DecodeVerifyPayload(payload []byte) (*big.Int, string, error) {
 	vals, err := payloadArgs.Unpack(payload)
 	if err != nil {
@@ -1,45 +1,131 @@
+// Package config loads the performer's configuration by layering, in
+// increasing precedence, built-in defaults, an optional file at
+// PERFORMER_CONFIG, PERFORMER_-prefixed environment variables, and CLI
+// flags. A Store on top of Load lets the RpcURL/IpfsGateway/etc. be hot
+// reloaded from the same file without restarting the performer.
 package config
 
 import (
 	"fmt"
 	"os"
-	"strconv"
+	"regexp"
+	"strings"
+	"time"
 )
 
 type Config struct {
 	RpcURL                      string
 	Erc721PatentRegistryAddress string
-	IpfsGateway                 string
-	SchemaURI                   string
-	Port                        int
+	ChainID                     string // CAIP-2, e.g. "eip155:1"
+	Registries                  []RegistryEntry
+
+	IpfsGateway    string // primary gateway (back-compat / native-mode fetches)
+	IpfsGateways   []string
+	GatewayEntries []GatewayEntry
+	IpfsMode       string
+	QuorumK        int
+	QuorumN        int
+
+	SchemaURI string
+	PolicyURI string
+
+	Mode        string
+	TaskTimeout time.Duration
+	Port        int
+}
+
+// RegistryEntry pairs a CAIP-2 chain ID with the registry contract deployed
+// on it, so a performer can serve more than one chain.
+type RegistryEntry struct {
+	ChainID string `json:"chainId" yaml:"chainId"`
+	Address string `json:"address" yaml:"address"`
+}
+
+// GatewayEntry is a configured IPFS gateway used by the quorum fetcher.
+type GatewayEntry struct {
+	URL string `json:"url" yaml:"url"`
 }
 
-func getenv(key, def string) string {
-	if v := os.Getenv(key); v != "" {
-		return v
+func defaults() *Config {
+	return &Config{
+		IpfsMode:    "gateway",
+		Mode:        "pull",
+		TaskTimeout: 20 * time.Second,
+		Port:        8080,
+		QuorumK:     1,
+		QuorumN:     1,
 	}
-	return def
 }
 
+// Load builds the Config by layering defaults, the file at PERFORMER_CONFIG
+// (if set), PERFORMER_ environment variables, and CLI flags, in that order
+// of increasing precedence.
 func Load() (*Config, error) {
-	cfg := &Config{
-		RpcURL:                      getenv("PERFORMER_RPC_URL", ""),
-		Erc721PatentRegistryAddress: getenv("PERFORMER_ERC721_PATENT_REGISTRY_ADDRESS", ""),
-		IpfsGateway:                 getenv("PERFORMER_IPFS_GATEWAY", ""),
-		SchemaURI:                   getenv("PERFORMER_SCHEMA_URI", ""),
-	}
-	portStr := getenv("PERFORMER_PORT", "8080")
-	p, err := strconv.Atoi(portStr)
-	if err != nil {
-		return nil, fmt.Errorf("invalid PERFORMER_PORT: %w", err)
-	}
-	cfg.Port = p
+	cfg := defaults()
+
+	if path := os.Getenv("PERFORMER_CONFIG"); path != "" {
+		fc, err := loadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("load config file %s: %w", path, err)
+		}
+		if err := fc.applyTo(cfg); err != nil {
+			return nil, fmt.Errorf("apply config file %s: %w", path, err)
+		}
+	}
+	if err := applyEnv(cfg); err != nil {
+		return nil, err
+	}
+	if err := applyFlags(cfg, os.Args[1:]); err != nil {
+		return nil, err
+	}
+
+	finalize(cfg)
 	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
 	return cfg, nil
 }
 
+// finalize derives computed fields (the flat gateway list, the registry
+// address for the configured chain) after every layer has been applied.
+func finalize(cfg *Config) {
+	cfg.IpfsGateways = gatewayURLs(cfg)
+	if cfg.Erc721PatentRegistryAddress == "" {
+		for _, r := range cfg.Registries {
+			if r.ChainID == cfg.ChainID {
+				cfg.Erc721PatentRegistryAddress = r.Address
+				break
+			}
+		}
+	}
+}
+
+func gatewayURLs(cfg *Config) []string {
+	var out []string
+	for _, g := range cfg.GatewayEntries {
+		if g.URL != "" {
+			out = append(out, g.URL)
+		}
+	}
+	if len(out) == 0 && cfg.IpfsGateway != "" {
+		out = []string{cfg.IpfsGateway}
+	}
+	return out
+}
+
+func parseGateways(csv string) []GatewayEntry {
+	var out []GatewayEntry
+	for _, g := range strings.Split(csv, ",") {
+		if g = strings.TrimSpace(g); g != "" {
+			out = append(out, GatewayEntry{URL: g})
+		}
+	}
+	return out
+}
+
+// caip2Re matches a CAIP-2 chain id: namespace ":" reference, e.g. "eip155:1".
+var caip2Re = regexp.MustCompile(`^[-a-z0-9]{3,8}:[-a-zA-Z0-9]{1,32}$`)
+
 func (c *Config) Validate() error {
 	missing := func(k string) error { return fmt.Errorf("missing %s", k) }
 	if c.RpcURL == "" {
@@ -48,9 +134,37 @@ func (c *Config) Validate() error {
 	if c.Erc721PatentRegistryAddress == "" {
 		return missing("PERFORMER_ERC721_PATENT_REGISTRY_ADDRESS")
 	}
-	if c.IpfsGateway == "" {
+	if c.ChainID != "" && !caip2Re.MatchString(c.ChainID) {
+		return fmt.Errorf("invalid PERFORMER_CHAIN_ID %q: not a valid CAIP-2 chain id", c.ChainID)
+	}
+	for _, r := range c.Registries {
+		if !caip2Re.MatchString(r.ChainID) {
+			return fmt.Errorf("invalid registry chain id %q: not a valid CAIP-2 chain id", r.ChainID)
+		}
+	}
+	if c.IpfsGateway == "" && len(c.IpfsGateways) == 0 {
 		return missing("PERFORMER_IPFS_GATEWAY")
 	}
-	// SchemaURI is optional; when empty, worker skips schema fetch
+	switch c.IpfsMode {
+	case "gateway", "native":
+	default:
+		return fmt.Errorf("invalid PERFORMER_IPFS_MODE: %s", c.IpfsMode)
+	}
+	if c.QuorumN < 1 {
+		return fmt.Errorf("invalid PERFORMER_IPFS_QUORUM_N: %d", c.QuorumN)
+	}
+	if c.QuorumK < 1 || c.QuorumK > c.QuorumN {
+		return fmt.Errorf("invalid PERFORMER_IPFS_QUORUM_K: %d (must be between 1 and QUORUM_N=%d)", c.QuorumK, c.QuorumN)
+	}
+	if c.QuorumK <= c.QuorumN/2 {
+		return fmt.Errorf("invalid PERFORMER_IPFS_QUORUM_K: %d (must be a strict majority of QUORUM_N=%d, i.e. > %d) to rule out two equally-sized gateway groups both reaching quorum", c.QuorumK, c.QuorumN, c.QuorumN/2)
+	}
+	switch c.Mode {
+	case "pull", "push", "both":
+	default:
+		return fmt.Errorf("invalid PERFORMER_MODE: %s", c.Mode)
+	}
+	// SchemaURI and PolicyURI are optional; when empty, the worker skips
+	// schema and policy evaluation respectively.
 	return nil
 }
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// applyEnv overrides cfg with any PERFORMER_-prefixed environment variable
+// that is actually set, leaving everything else (defaults or file values)
+// untouched.
+func applyEnv(cfg *Config) error {
+	if v, ok := os.LookupEnv("PERFORMER_RPC_URL"); ok {
+		cfg.RpcURL = v
+	}
+	if v, ok := os.LookupEnv("PERFORMER_ERC721_PATENT_REGISTRY_ADDRESS"); ok {
+		cfg.Erc721PatentRegistryAddress = v
+	}
+	if v, ok := os.LookupEnv("PERFORMER_CHAIN_ID"); ok {
+		cfg.ChainID = v
+	}
+	if v, ok := os.LookupEnv("PERFORMER_IPFS_GATEWAY"); ok {
+		cfg.IpfsGateway = v
+	}
+	if v, ok := os.LookupEnv("PERFORMER_IPFS_GATEWAYS"); ok {
+		cfg.GatewayEntries = parseGateways(v)
+	}
+	if v, ok := os.LookupEnv("PERFORMER_IPFS_MODE"); ok {
+		cfg.IpfsMode = v
+	}
+	if v, ok := os.LookupEnv("PERFORMER_SCHEMA_URI"); ok {
+		cfg.SchemaURI = v
+	}
+	if v, ok := os.LookupEnv("PERFORMER_POLICY_URI"); ok {
+		cfg.PolicyURI = v
+	}
+	if v, ok := os.LookupEnv("PERFORMER_MODE"); ok {
+		cfg.Mode = v
+	}
+
+	if err := applyEnvInt("PERFORMER_PORT", &cfg.Port); err != nil {
+		return err
+	}
+	if err := applyEnvInt("PERFORMER_IPFS_QUORUM_K", &cfg.QuorumK); err != nil {
+		return err
+	}
+	if err := applyEnvInt("PERFORMER_IPFS_QUORUM_N", &cfg.QuorumN); err != nil {
+		return err
+	}
+	if v, ok := os.LookupEnv("PERFORMER_TASK_TIMEOUT"); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid PERFORMER_TASK_TIMEOUT: %w", err)
+		}
+		cfg.TaskTimeout = d
+	}
+	return nil
+}
+
+func applyEnvInt(key string, dst *int) error {
+	v, ok := os.LookupEnv(key)
+	if !ok {
+		return nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	*dst = n
+	return nil
+}
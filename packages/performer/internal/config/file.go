@@ -0,0 +1,98 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileConfig mirrors Config but with pointers, so a field left out of the
+// file doesn't clobber a default or an env-set value when layered later.
+type fileConfig struct {
+	RpcURL                      *string         `json:"rpcUrl" yaml:"rpcUrl"`
+	Erc721PatentRegistryAddress *string         `json:"erc721PatentRegistryAddress" yaml:"erc721PatentRegistryAddress"`
+	ChainID                     *string         `json:"chainId" yaml:"chainId"`
+	Registries                  []RegistryEntry `json:"registries" yaml:"registries"`
+	IpfsGateway                 *string         `json:"ipfsGateway" yaml:"ipfsGateway"`
+	GatewayEntries              []GatewayEntry  `json:"gateways" yaml:"gateways"`
+	IpfsMode                    *string         `json:"ipfsMode" yaml:"ipfsMode"`
+	QuorumK                     *int            `json:"quorumK" yaml:"quorumK"`
+	QuorumN                     *int            `json:"quorumN" yaml:"quorumN"`
+	SchemaURI                   *string         `json:"schemaUri" yaml:"schemaUri"`
+	PolicyURI                   *string         `json:"policyUri" yaml:"policyUri"`
+	Mode                        *string         `json:"mode" yaml:"mode"`
+	TaskTimeout                 *string         `json:"taskTimeout" yaml:"taskTimeout"`
+	Port                        *int            `json:"port" yaml:"port"`
+}
+
+func loadFile(path string) (*fileConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fc fileConfig
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(b, &fc)
+	default:
+		err = json.Unmarshal(b, &fc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse config file: %w", err)
+	}
+	return &fc, nil
+}
+
+func (fc *fileConfig) applyTo(cfg *Config) error {
+	if fc.RpcURL != nil {
+		cfg.RpcURL = *fc.RpcURL
+	}
+	if fc.Erc721PatentRegistryAddress != nil {
+		cfg.Erc721PatentRegistryAddress = *fc.Erc721PatentRegistryAddress
+	}
+	if fc.ChainID != nil {
+		cfg.ChainID = *fc.ChainID
+	}
+	if fc.Registries != nil {
+		cfg.Registries = fc.Registries
+	}
+	if fc.IpfsGateway != nil {
+		cfg.IpfsGateway = *fc.IpfsGateway
+	}
+	if fc.GatewayEntries != nil {
+		cfg.GatewayEntries = fc.GatewayEntries
+	}
+	if fc.IpfsMode != nil {
+		cfg.IpfsMode = *fc.IpfsMode
+	}
+	if fc.QuorumK != nil {
+		cfg.QuorumK = *fc.QuorumK
+	}
+	if fc.QuorumN != nil {
+		cfg.QuorumN = *fc.QuorumN
+	}
+	if fc.SchemaURI != nil {
+		cfg.SchemaURI = *fc.SchemaURI
+	}
+	if fc.PolicyURI != nil {
+		cfg.PolicyURI = *fc.PolicyURI
+	}
+	if fc.Mode != nil {
+		cfg.Mode = *fc.Mode
+	}
+	if fc.Port != nil {
+		cfg.Port = *fc.Port
+	}
+	if fc.TaskTimeout != nil {
+		d, err := time.ParseDuration(*fc.TaskTimeout)
+		if err != nil {
+			return fmt.Errorf("invalid taskTimeout: %w", err)
+		}
+		cfg.TaskTimeout = d
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+)
+
+// applyFlags overrides cfg with CLI flags, the highest-precedence layer.
+// Flags default to the current value of cfg so an unset flag is a no-op.
+func applyFlags(cfg *Config, args []string) error {
+	fs := flag.NewFlagSet("performer", flag.ContinueOnError)
+	rpcURL := fs.String("rpc-url", cfg.RpcURL, "Ethereum JSON-RPC URL")
+	registry := fs.String("erc721-registry", cfg.Erc721PatentRegistryAddress, "ERC-721 patent registry address or ENS name")
+	chainID := fs.String("chain-id", cfg.ChainID, "CAIP-2 chain id, e.g. eip155:1")
+	ipfsGateway := fs.String("ipfs-gateway", cfg.IpfsGateway, "primary IPFS gateway URL")
+	ipfsMode := fs.String("ipfs-mode", cfg.IpfsMode, "gateway|native")
+	schemaURI := fs.String("schema-uri", cfg.SchemaURI, "ipfs:// URI of the JSON Schema to validate new metadata against")
+	policyURI := fs.String("policy-uri", cfg.PolicyURI, "ipfs:// URI of the policy rules document")
+	mode := fs.String("mode", cfg.Mode, "pull|push|both")
+	taskTimeout := fs.Duration("task-timeout", cfg.TaskTimeout, "per-task deadline")
+	port := fs.Int("port", cfg.Port, "RPC server port")
+
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	cfg.RpcURL = *rpcURL
+	cfg.Erc721PatentRegistryAddress = *registry
+	cfg.ChainID = *chainID
+	cfg.IpfsGateway = *ipfsGateway
+	cfg.IpfsMode = *ipfsMode
+	cfg.SchemaURI = *schemaURI
+	cfg.PolicyURI = *policyURI
+	cfg.Mode = *mode
+	cfg.TaskTimeout = *taskTimeout
+	cfg.Port = *port
+	return nil
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Store holds the live Config behind an atomic pointer so readers (the
+// VerifierWorker, in the hot path) never block on a reload, and lets
+// interested parties register a callback for fields whose consumers must be
+// rebuilt rather than just re-read — namely RpcURL (eth.Client),
+// Erc721PatentRegistryAddress (ENS resolution of the registry address) and
+// IpfsGateway (ipfs.Client).
+type Store struct {
+	ptr atomic.Pointer[Config]
+
+	mu               sync.Mutex
+	onRpcURLChange   []func(*Config)
+	onRegistryChange []func(*Config)
+	onGatewayChange  []func(*Config)
+}
+
+// NewStore wraps an already-loaded Config for atomic access and hot reload.
+func NewStore(initial *Config) *Store {
+	s := &Store{}
+	s.ptr.Store(initial)
+	return s
+}
+
+// Get returns the current Config. The returned value must be treated as
+// immutable; a reload swaps in a new *Config rather than mutating this one.
+func (s *Store) Get() *Config {
+	return s.ptr.Load()
+}
+
+// OnRpcURLChange registers a callback invoked (with the new Config) whenever
+// a reload changes RpcURL, so eth.Client can be rebuilt against the new
+// endpoint instead of reconnecting lazily.
+func (s *Store) OnRpcURLChange(cb func(*Config)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onRpcURLChange = append(s.onRpcURLChange, cb)
+}
+
+// OnRegistryChange registers a callback invoked (with the new Config)
+// whenever a reload changes Erc721PatentRegistryAddress, so an ENS name can
+// be re-resolved before the new address is used, rather than left as an
+// unresolved string in the live Config.
+func (s *Store) OnRegistryChange(cb func(*Config)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onRegistryChange = append(s.onRegistryChange, cb)
+}
+
+// OnGatewayChange registers a callback invoked whenever a reload changes the
+// primary IPFS gateway or the resolved gateway list used by the quorum
+// fetcher, so ipfs.Client can be rebuilt.
+func (s *Store) OnGatewayChange(cb func(*Config)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onGatewayChange = append(s.onGatewayChange, cb)
+}
+
+// Watch reloads the config file at path whenever it changes on disk
+// (defaults and env are re-applied too; CLI flags are not, so a value set
+// only by a flag reverts to its file, env or default value on reload)
+// and atomically swaps it in, firing the registered reconnect callbacks for
+// any field that changed. It blocks until ctx is cancelled or the watch
+// fails to start; reload errors are swallowed so a bad edit doesn't tear
+// down a running performer — the last good Config stays live.
+func (s *Store) Watch(ctx context.Context, path string) error {
+	if path == "" {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+	if err := w.Add(path); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case ev, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+			if ev.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			s.reload(path)
+		case <-w.Errors:
+			// best-effort: keep watching on transient fsnotify errors
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (s *Store) reload(path string) {
+	next := defaults()
+	fc, err := loadFile(path)
+	if err != nil {
+		return
+	}
+	if err := fc.applyTo(next); err != nil {
+		return
+	}
+	if err := applyEnv(next); err != nil {
+		return
+	}
+	finalize(next)
+	if err := next.Validate(); err != nil {
+		return
+	}
+
+	prev := s.ptr.Swap(next)
+	if prev.RpcURL != next.RpcURL {
+		s.fireRpcURLChange(next)
+	} else if prev.Erc721PatentRegistryAddress != next.Erc721PatentRegistryAddress {
+		// RpcURL already triggers a full eth.Client rebuild (which also
+		// re-resolves ENS); avoid re-resolving twice when both changed in
+		// the same reload.
+		s.fireRegistryChange(next)
+	}
+	if prev.IpfsGateway != next.IpfsGateway || !gatewaysEqual(prev.IpfsGateways, next.IpfsGateways) {
+		s.fireGatewayChange(next)
+	}
+}
+
+// gatewaysEqual reports whether a and b list the same gateways in the same
+// order.
+func gatewaysEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Store) fireRpcURLChange(cfg *Config) {
+	s.mu.Lock()
+	cbs := append([]func(*Config){}, s.onRpcURLChange...)
+	s.mu.Unlock()
+	for _, cb := range cbs {
+		cb(cfg)
+	}
+}
+
+func (s *Store) fireRegistryChange(cfg *Config) {
+	s.mu.Lock()
+	cbs := append([]func(*Config){}, s.onRegistryChange...)
+	s.mu.Unlock()
+	for _, cb := range cbs {
+		cb(cfg)
+	}
+}
+
+func (s *Store) fireGatewayChange(cfg *Config) {
+	s.mu.Lock()
+	cbs := append([]func(*Config){}, s.onGatewayChange...)
+	s.mu.Unlock()
+	for _, cb := range cbs {
+		cb(cfg)
+	}
+}
@@ -0,0 +1,55 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTestConfig(t *testing.T, path, body string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+}
+
+func TestReloadFiresGatewayChangeOnGatewayListChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	writeTestConfig(t, path, `{
+		"rpcUrl": "http://rpc.example",
+		"erc721PatentRegistryAddress": "0x0000000000000000000000000000000000dEaD",
+		"gateways": [{"url": "http://gw1.example"}]
+	}`)
+
+	s := NewStore(defaults())
+	var gatewayChanges int
+	s.OnGatewayChange(func(*Config) { gatewayChanges++ })
+
+	s.reload(path)
+	if gatewayChanges != 1 {
+		t.Fatalf("expected 1 gateway change after first reload, got %d", gatewayChanges)
+	}
+
+	// Changing the gateway list (not IpfsGateway) must still fire.
+	writeTestConfig(t, path, `{
+		"rpcUrl": "http://rpc.example",
+		"erc721PatentRegistryAddress": "0x0000000000000000000000000000000000dEaD",
+		"gateways": [{"url": "http://gw1.example"}, {"url": "http://gw2.example"}]
+	}`)
+	s.reload(path)
+	if gatewayChanges != 2 {
+		t.Fatalf("expected 2 gateway changes after gateway list grew, got %d", gatewayChanges)
+	}
+
+	// An unrelated field changing must not re-fire.
+	writeTestConfig(t, path, `{
+		"rpcUrl": "http://rpc.example",
+		"erc721PatentRegistryAddress": "0x0000000000000000000000000000000000dEaD",
+		"gateways": [{"url": "http://gw1.example"}, {"url": "http://gw2.example"}],
+		"schemaUri": "ipfs://newschema"
+	}`)
+	s.reload(path)
+	if gatewayChanges != 2 {
+		t.Fatalf("expected gateway changes to stay at 2 after an unrelated field change, got %d", gatewayChanges)
+	}
+}
@@ -0,0 +1,141 @@
+package eth
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ipfs/go-cid"
+)
+
+// ensRegistryAddress is the canonical ENS registry, deployed at the same
+// address on mainnet and every major testnet.
+var ensRegistryAddress = common.HexToAddress("0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e")
+
+var ensRegistryABI = `[{"constant":true,"inputs":[{"name":"node","type":"bytes32"}],"name":"resolver","outputs":[{"name":"","type":"address"}],"stateMutability":"view","type":"function"}]`
+
+var ensResolverABI = `[
+	{"constant":true,"inputs":[{"name":"node","type":"bytes32"}],"name":"addr","outputs":[{"name":"","type":"address"}],"stateMutability":"view","type":"function"},
+	{"constant":true,"inputs":[{"name":"node","type":"bytes32"}],"name":"contenthash","outputs":[{"name":"","type":"bytes"}],"stateMutability":"view","type":"function"}
+]`
+
+// contenthash multicodec table (EIP-1577).
+const (
+	codecIPFSNS  uint64 = 0xe3
+	codecSwarmNS uint64 = 0xe4
+	codecIPNSNS  uint64 = 0xe5
+)
+
+// ResolveName resolves an ENS name (e.g. "patents.eth") to the address held
+// by its resolver's addr() record, so registry addresses can be configured
+// as names instead of hex addresses.
+func (c *Client) ResolveName(ctx context.Context, name string) (common.Address, error) {
+	resolver, err := c.ensResolver(ctx, name)
+	if err != nil {
+		return common.Address{}, err
+	}
+	node := namehash(name)
+	var out []interface{}
+	if err := resolver.Call(&bind.CallOpts{Context: ctx}, &out, "addr", node); err != nil {
+		return common.Address{}, fmt.Errorf("call resolver.addr(%s): %w", name, err)
+	}
+	addr, ok := out[0].(common.Address)
+	if !ok {
+		return common.Address{}, fmt.Errorf("resolver.addr(%s) did not return an address", name)
+	}
+	if addr == (common.Address{}) {
+		return common.Address{}, fmt.Errorf("ens name %q has no addr record", name)
+	}
+	return addr, nil
+}
+
+// ResolveContenthash resolves an ENS name's EIP-1577 contenthash record and
+// returns it as an "ipfs://<cid>" URI, so patent owners can update metadata
+// by changing an ENS record instead of pushing a new tokenURI on-chain.
+// Unsupported codecs (anything but ipfs-ns) are rejected.
+func (c *Client) ResolveContenthash(ctx context.Context, name string) (string, error) {
+	resolver, err := c.ensResolver(ctx, name)
+	if err != nil {
+		return "", err
+	}
+	node := namehash(name)
+	var out []interface{}
+	if err := resolver.Call(&bind.CallOpts{Context: ctx}, &out, "contenthash", node); err != nil {
+		return "", fmt.Errorf("call resolver.contenthash(%s): %w", name, err)
+	}
+	raw, ok := out[0].([]byte)
+	if !ok || len(raw) == 0 {
+		return "", fmt.Errorf("ens name %q has no contenthash record", name)
+	}
+	return decodeContenthash(raw)
+}
+
+func (c *Client) ensResolver(ctx context.Context, name string) (*bind.BoundContract, error) {
+	registryABI, err := abi.JSON(strings.NewReader(ensRegistryABI))
+	if err != nil {
+		return nil, fmt.Errorf("parse ens registry abi: %w", err)
+	}
+	registry := bind.NewBoundContract(ensRegistryAddress, registryABI, c.rpc, c.rpc, c.rpc)
+
+	node := namehash(name)
+	var out []interface{}
+	if err := registry.Call(&bind.CallOpts{Context: ctx}, &out, "resolver", node); err != nil {
+		return nil, fmt.Errorf("call registry.resolver(%s): %w", name, err)
+	}
+	resolverAddr, ok := out[0].(common.Address)
+	if !ok || resolverAddr == (common.Address{}) {
+		return nil, fmt.Errorf("ens name %q has no resolver set", name)
+	}
+
+	resolverABI, err := abi.JSON(strings.NewReader(ensResolverABI))
+	if err != nil {
+		return nil, fmt.Errorf("parse ens resolver abi: %w", err)
+	}
+	return bind.NewBoundContract(resolverAddr, resolverABI, c.rpc, c.rpc, c.rpc), nil
+}
+
+// namehash implements the ENS namehash algorithm (EIP-137): the node for
+// "" is the zero hash, and each label is folded in from the right.
+func namehash(name string) [32]byte {
+	var node [32]byte
+	if name == "" {
+		return node
+	}
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		labelHash := crypto.Keccak256Hash([]byte(labels[i]))
+		node = crypto.Keccak256Hash(node[:], labelHash[:])
+	}
+	return node
+}
+
+// decodeContenthash parses an EIP-1577 contenthash value: a varint
+// multicodec prefix (the namespace, e.g. ipfs-ns) followed by the binary
+// CID. Only ipfs-ns is supported for metadata URIs.
+func decodeContenthash(raw []byte) (string, error) {
+	codec, n := binary.Uvarint(raw)
+	if n <= 0 {
+		return "", fmt.Errorf("invalid contenthash: bad multicodec varint")
+	}
+	rest := raw[n:]
+
+	switch codec {
+	case codecIPFSNS:
+		c, err := cid.Cast(rest)
+		if err != nil {
+			return "", fmt.Errorf("invalid ipfs contenthash cid: %w", err)
+		}
+		return "ipfs://" + c.String(), nil
+	case codecIPNSNS:
+		return "", fmt.Errorf("unsupported contenthash codec ipns-ns (0x%x): metadata URIs must resolve to ipfs-ns", codec)
+	case codecSwarmNS:
+		return "", fmt.Errorf("unsupported contenthash codec swarm-ns (0x%x): metadata URIs must resolve to ipfs-ns", codec)
+	default:
+		return "", fmt.Errorf("unsupported contenthash codec: 0x%x", codec)
+	}
+}
@@ -0,0 +1,63 @@
+package eth
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+)
+
+func TestEnsRegistryAddress(t *testing.T) {
+	const want = "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"
+	if got := ensRegistryAddress.Hex(); got != want {
+		t.Fatalf("ensRegistryAddress = %s, want canonical ENS registry %s", got, want)
+	}
+}
+
+func TestNamehash(t *testing.T) {
+	// Reference value from the EIP-137 spec / ENS docs.
+	const wantEth = "93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae"
+	if got := namehash("eth"); fmt.Sprintf("%x", got[:]) != wantEth {
+		t.Fatalf("namehash(%q) = %x, want %s", "eth", got, wantEth)
+	}
+
+	if got := namehash(""); got != ([32]byte{}) {
+		t.Fatalf("namehash(\"\") = %x, want the zero hash", got)
+	}
+}
+
+func TestDecodeContenthashIPFSNS(t *testing.T) {
+	sum, err := mh.Sum([]byte("hello ipfs"), mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatalf("sum: %v", err)
+	}
+	id := cid.NewCidV1(cid.Raw, sum)
+
+	raw := encodeContenthash(codecIPFSNS, id.Bytes())
+	got, err := decodeContenthash(raw)
+	if err != nil {
+		t.Fatalf("decodeContenthash: %v", err)
+	}
+	if want := "ipfs://" + id.String(); got != want {
+		t.Fatalf("decodeContenthash = %s, want %s", got, want)
+	}
+}
+
+func TestDecodeContenthashRejectsUnsupportedCodecs(t *testing.T) {
+	for _, codec := range []uint64{codecIPNSNS, codecSwarmNS} {
+		raw := encodeContenthash(codec, []byte("irrelevant"))
+		if _, err := decodeContenthash(raw); err == nil {
+			t.Fatalf("decodeContenthash with codec 0x%x: expected an error, got nil", codec)
+		}
+	}
+}
+
+// encodeContenthash builds an EIP-1577 contenthash value: a varint
+// multicodec prefix followed by the namespace-specific payload.
+func encodeContenthash(codec uint64, payload []byte) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(buf, codec)
+	return append(buf[:n], payload...)
+}
@@ -9,31 +9,79 @@ import (
 	"time"
 )
 
+// Mode selects how Client fetches content behind an ipfs:// URI.
+type Mode string
+
+const (
+	// ModeGateway trusts a single HTTP gateway to return the right bytes
+	// for a path, the original behavior.
+	ModeGateway Mode = "gateway"
+	// ModeNative parses the CID out of the URI, fetches the raw block,
+	// and recomputes its multihash before trusting the bytes.
+	ModeNative Mode = "native"
+)
+
 type Client struct {
-	gateway string
-	hc      *http.Client
+	gateway  string // primary gateway, used by Resolve/Fetch/native mode
+	gateways []*gatewayState
+	mode     Mode
+	hc       *http.Client
 }
 
-func NewClient(gateway string) *Client {
+// NewClient builds a Client backed by one or more gateways. The first
+// gateway is the primary used by Resolve, Fetch and native-mode block
+// fetches; FetchQuorum fans out across all of them.
+func NewClient(gateways []string, mode Mode) *Client {
+	if mode == "" {
+		mode = ModeGateway
+	}
+	states := make([]*gatewayState, 0, len(gateways))
+	for _, g := range gateways {
+		states = append(states, &gatewayState{url: strings.TrimRight(g, "/")})
+	}
+	primary := ""
+	if len(states) > 0 {
+		primary = states[0].url
+	}
 	return &Client{
-		gateway: strings.TrimRight(gateway, "/"),
-		hc:      &http.Client{Timeout: 15 * time.Second},
+		gateway:  primary,
+		gateways: states,
+		mode:     mode,
+		hc:       &http.Client{Timeout: 15 * time.Second},
 	}
 }
 
 func (c *Client) Resolve(uri string) (string, error) {
+	return c.resolveAgainst(c.gateway, uri)
+}
+
+func (c *Client) resolveAgainst(gateway, uri string) (string, error) {
 	if !strings.HasPrefix(uri, "ipfs://") {
 		return "", fmt.Errorf("unsupported uri scheme: %s", uri)
 	}
 	rest := strings.TrimPrefix(uri, "ipfs://")
-	return c.gateway + "/" + rest, nil
+	return gateway + "/" + rest, nil
 }
 
+// Fetch retrieves the bytes behind uri, either trusting the configured
+// gateway (ModeGateway) or verifying the CID's multihash against the
+// returned bytes (ModeNative).
 func (c *Client) Fetch(ctx context.Context, uri string, maxBytes int64) ([]byte, error) {
+	if c.mode == ModeNative {
+		return c.fetchNative(ctx, uri, maxBytes)
+	}
+	return c.fetchGateway(ctx, uri, maxBytes)
+}
+
+func (c *Client) fetchGateway(ctx context.Context, uri string, maxBytes int64) ([]byte, error) {
 	url, err := c.Resolve(uri)
 	if err != nil {
 		return nil, err
 	}
+	return c.httpGet(ctx, url, maxBytes)
+}
+
+func (c *Client) httpGet(ctx context.Context, url string, maxBytes int64) ([]byte, error) {
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	resp, err := c.hc.Do(req)
 	if err != nil {
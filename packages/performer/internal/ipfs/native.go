@@ -0,0 +1,163 @@
+package ipfs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	ipld "github.com/ipfs/go-ipld-format"
+	dag "github.com/ipfs/go-merkledag"
+	ft "github.com/ipfs/go-unixfs"
+	uio "github.com/ipfs/go-unixfs/io"
+	"github.com/ipfs/go-verifcid"
+	mh "github.com/multiformats/go-multihash"
+)
+
+// fetchNative fetches the block(s) behind uri from the primary gateway using
+// ?format=raw, recomputes the multihash over the bytes, and rejects the
+// response if it doesn't match the CID embedded in uri. dag-pb/UnixFS
+// payloads are walked and reassembled, honoring maxBytes.
+func (c *Client) fetchNative(ctx context.Context, uri string, maxBytes int64) ([]byte, error) {
+	return c.fetchNativeAgainst(ctx, c.gateway, uri, maxBytes)
+}
+
+// fetchNativeAgainst is fetchNative against a specific gateway rather than
+// c.gateway, so FetchQuorum can verify each gateway's response by hash
+// instead of trusting it outright.
+func (c *Client) fetchNativeAgainst(ctx context.Context, gateway, uri string, maxBytes int64) ([]byte, error) {
+	root, err := parseCID(uri)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifcid.ValidateCid(root); err != nil {
+		return nil, fmt.Errorf("disallowed cid: %w", err)
+	}
+
+	dagService := &fetcherDAG{client: c, gateway: gateway}
+	node, err := dagService.Get(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+
+	switch root.Prefix().Codec {
+	case cid.DagProtobuf:
+		pn, ok := node.(*dag.ProtoNode)
+		if !ok {
+			return nil, fmt.Errorf("expected dag-pb node for %s", root)
+		}
+		return readUnixFS(ctx, dagService, pn, maxBytes)
+	default:
+		// raw / cbor / other leaf codecs: the block itself is the file.
+		return node.RawData(), nil
+	}
+}
+
+// parseCID extracts and decodes the CID from an ipfs:// URI, accepting both
+// CIDv0 (base58btc sha2-256) and CIDv1 (multibase + multicodec) forms.
+func parseCID(uri string) (cid.Cid, error) {
+	if !strings.HasPrefix(uri, "ipfs://") {
+		return cid.Undef, fmt.Errorf("unsupported uri scheme: %s", uri)
+	}
+	rest := strings.TrimPrefix(uri, "ipfs://")
+	if i := strings.IndexAny(rest, "/#?"); i >= 0 {
+		rest = rest[:i]
+	}
+	c, err := cid.Decode(rest)
+	if err != nil {
+		return cid.Undef, fmt.Errorf("invalid cid %q: %w", rest, err)
+	}
+	return c, nil
+}
+
+// fetchBlock retrieves the raw block for id from gateway and verifies its
+// multihash matches id before returning it.
+func (c *Client) fetchBlock(ctx context.Context, gateway string, id cid.Cid) ([]byte, error) {
+	url := fmt.Sprintf("%s/ipfs/%s?format=raw", gateway, id.String())
+	b, err := c.httpGet(ctx, url, 0)
+	if err != nil {
+		return nil, fmt.Errorf("fetch block %s: %w", id, err)
+	}
+	decoded, err := mh.Decode([]byte(id.Hash()))
+	if err != nil {
+		return nil, fmt.Errorf("decode multihash: %w", err)
+	}
+	sum, err := mh.Sum(b, decoded.Code, decoded.Length)
+	if err != nil {
+		return nil, fmt.Errorf("hash block: %w", err)
+	}
+	if !bytes.Equal(sum, id.Hash()) {
+		return nil, fmt.Errorf("cid mismatch: gateway returned content that does not hash to %s", id)
+	}
+	return b, nil
+}
+
+// fetcherDAG adapts Client.fetchBlock to the ipld.NodeGetter interface
+// expected by go-unixfs, fetching each block (and verifying its hash) on
+// demand rather than trusting a pre-fetched tree.
+type fetcherDAG struct {
+	client  *Client
+	gateway string
+}
+
+func (d *fetcherDAG) Get(ctx context.Context, id cid.Cid) (ipld.Node, error) {
+	b, err := d.client.fetchBlock(ctx, d.gateway, id)
+	if err != nil {
+		return nil, err
+	}
+	if id.Prefix().Codec == cid.DagProtobuf {
+		return dag.DecodeProtobuf(b)
+	}
+	return dag.NewRawNode(b), nil
+}
+
+// GetMany fetches each requested block sequentially through Get, satisfying
+// ipld.NodeGetter for callers (go-unixfs's DagReader) that fetch several
+// links at once.
+func (d *fetcherDAG) GetMany(ctx context.Context, ids []cid.Cid) <-chan *ipld.NodeOption {
+	out := make(chan *ipld.NodeOption, len(ids))
+	go func() {
+		defer close(out)
+		for _, id := range ids {
+			n, err := d.Get(ctx, id)
+			select {
+			case out <- &ipld.NodeOption{Node: n, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// readUnixFS walks a dag-pb/UnixFS node's links and reassembles the file it
+// describes, stopping once maxBytes have been read (when maxBytes > 0).
+func readUnixFS(ctx context.Context, dagService *fetcherDAG, node *dag.ProtoNode, maxBytes int64) ([]byte, error) {
+	fsNode, err := ft.FSNodeFromBytes(node.Data())
+	if err != nil {
+		return nil, fmt.Errorf("decode unixfs node: %w", err)
+	}
+	if !fsNode.IsDir() {
+		r, err := uio.NewDagReader(ctx, node, dagService)
+		if err != nil {
+			return nil, fmt.Errorf("open unixfs reader: %w", err)
+		}
+		defer r.Close()
+		return readLimited(r, maxBytes)
+	}
+	return nil, fmt.Errorf("unixfs directories are not supported for metadata URIs")
+}
+
+func readLimited(r io.Reader, maxBytes int64) ([]byte, error) {
+	if maxBytes > 0 {
+		r = io.LimitReader(r, maxBytes)
+	}
+	out, err := io.ReadAll(r)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,74 @@
+package ipfs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+)
+
+func TestFetchBlockVerifiesHash(t *testing.T) {
+	data := []byte("hello ipfs")
+	sum, err := mh.Sum(data, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatalf("sum: %v", err)
+	}
+	id := cid.NewCidV1(cid.Raw, sum)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(data)
+	}))
+	defer srv.Close()
+
+	c := NewClient([]string{srv.URL}, ModeNative)
+	got, err := c.fetchBlock(context.Background(), srv.URL, id)
+	if err != nil {
+		t.Fatalf("fetchBlock: %v", err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("got %q, want %q", got, data)
+	}
+}
+
+func TestFetchBlockRejectsHashMismatch(t *testing.T) {
+	data := []byte("hello ipfs")
+	sum, err := mh.Sum(data, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatalf("sum: %v", err)
+	}
+	id := cid.NewCidV1(cid.Raw, sum)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("tampered bytes"))
+	}))
+	defer srv.Close()
+
+	c := NewClient([]string{srv.URL}, ModeNative)
+	if _, err := c.fetchBlock(context.Background(), srv.URL, id); err == nil {
+		t.Fatal("expected hash mismatch error, got nil")
+	}
+}
+
+func TestParseCID(t *testing.T) {
+	data := []byte("hello ipfs")
+	sum, err := mh.Sum(data, mh.SHA2_256, -1)
+	if err != nil {
+		t.Fatalf("sum: %v", err)
+	}
+	id := cid.NewCidV1(cid.Raw, sum)
+
+	got, err := parseCID("ipfs://" + id.String() + "/metadata.json")
+	if err != nil {
+		t.Fatalf("parseCID: %v", err)
+	}
+	if !got.Equals(id) {
+		t.Fatalf("got %s, want %s", got, id)
+	}
+
+	if _, err := parseCID("https://example.com/x"); err == nil {
+		t.Fatal("expected error for non-ipfs scheme")
+	}
+}
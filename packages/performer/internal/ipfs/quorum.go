@@ -0,0 +1,250 @@
+package ipfs
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	circuitBreakerThreshold = 3                // consecutive failures before a gateway trips
+	circuitBreakerCooldown  = 30 * time.Second // how long a tripped gateway is skipped
+	retryBaseDelay          = 200 * time.Millisecond
+	retryMaxAttempts        = 3
+)
+
+// GatewayMetrics is a point-in-time snapshot of a single gateway's health.
+type GatewayMetrics struct {
+	URL         string
+	Successes   int64
+	Failures    int64
+	LastLatency time.Duration
+	CircuitOpen bool
+}
+
+// gatewayState tracks per-gateway health for the circuit breaker and metrics.
+type gatewayState struct {
+	url string
+
+	mu                  sync.Mutex
+	successes, failures int64
+	lastLatency         time.Duration
+	consecutiveFailures int
+	openUntil           time.Time
+}
+
+func (g *gatewayState) available(now time.Time) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return now.After(g.openUntil)
+}
+
+func (g *gatewayState) recordSuccess(latency time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.successes++
+	g.lastLatency = latency
+	g.consecutiveFailures = 0
+	g.openUntil = time.Time{}
+}
+
+func (g *gatewayState) recordFailure() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.failures++
+	g.consecutiveFailures++
+	if g.consecutiveFailures >= circuitBreakerThreshold {
+		g.openUntil = time.Now().Add(circuitBreakerCooldown)
+	}
+}
+
+func (g *gatewayState) snapshot() GatewayMetrics {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return GatewayMetrics{
+		URL:         g.url,
+		Successes:   g.successes,
+		Failures:    g.failures,
+		LastLatency: g.lastLatency,
+		CircuitOpen: time.Now().Before(g.openUntil),
+	}
+}
+
+// Metrics returns a snapshot of health for every configured gateway.
+func (c *Client) Metrics() []GatewayMetrics {
+	out := make([]GatewayMetrics, 0, len(c.gateways))
+	for _, g := range c.gateways {
+		out = append(out, g.snapshot())
+	}
+	return out
+}
+
+type gatewayResult struct {
+	hash [sha256.Size]byte
+	body []byte
+	err  error
+	url  string
+}
+
+// FetchQuorum fetches uri from up to n configured gateways in parallel and
+// returns the body only if at least k of the responses hash identically,
+// guarding against any single gateway serving manipulated content. Gateways
+// that have tripped their circuit breaker (circuitBreakerThreshold
+// consecutive failed fetches of any kind; 5xx responses and timeouts are not
+// told apart from other errors) are skipped; each gateway gets
+// exponential-backoff retries within its own fetch attempt.
+func (c *Client) FetchQuorum(ctx context.Context, uri string, maxBytes int64, k, n int) ([]byte, error) {
+	if len(c.gateways) == 0 {
+		return nil, fmt.Errorf("no gateways configured")
+	}
+	if n > len(c.gateways) {
+		n = len(c.gateways)
+	}
+	if k > n {
+		k = n
+	}
+
+	candidates := make([]*gatewayState, 0, n)
+	now := time.Now()
+	for _, g := range c.gateways {
+		if len(candidates) >= n {
+			break
+		}
+		if g.available(now) {
+			candidates = append(candidates, g)
+		}
+	}
+	if len(candidates) < k {
+		return nil, fmt.Errorf("no quorum: only %d/%d gateways available (need %d)", len(candidates), n, k)
+	}
+
+	results := make(chan gatewayResult, len(candidates))
+	var wg sync.WaitGroup
+	for _, g := range candidates {
+		wg.Add(1)
+		go func(g *gatewayState) {
+			defer wg.Done()
+			body, err := c.fetchWithRetry(ctx, g, uri, maxBytes)
+			res := gatewayResult{err: err, url: g.url}
+			if err == nil {
+				res.body = body
+				res.hash = sha256.Sum256(body)
+			}
+			results <- res
+		}(g)
+	}
+	wg.Wait()
+	close(results)
+
+	groups := make(map[[sha256.Size]byte][]gatewayResult)
+	var lastErr error
+	for r := range results {
+		if r.err != nil {
+			lastErr = r.err
+			continue
+		}
+		groups[r.hash] = append(groups[r.hash], r)
+	}
+
+	var winner *gatewayResult
+	winners := 0
+	for _, group := range groups {
+		if len(group) >= k {
+			winners++
+			winner = &group[0]
+		}
+	}
+	if winners > 1 {
+		// Two (or more) equally-sized groups both reached k: config.Validate
+		// requires k > n/2, so this can only happen with a bad config or
+		// gateways split into colluding factions — either way, picking one
+		// arbitrarily would mean trusting attacker-controlled bytes roughly
+		// half the time. Fail closed instead of guessing.
+		return nil, fmt.Errorf("no quorum: %d distinct groups of %d+ gateways disagree, cannot pick a winner", winners, k)
+	}
+	if winner != nil {
+		return winner.body, nil
+	}
+	if lastErr != nil {
+		return nil, fmt.Errorf("no quorum: responses did not agree and some gateways failed, last error: %w", lastErr)
+	}
+	return nil, fmt.Errorf("no quorum: got %d distinct results from %d gateways, need %d to agree", len(groups), len(candidates), k)
+}
+
+// fetchWithRetry fetches uri from a single gateway, retrying with
+// exponential backoff on any error (the kind of error is not examined) and
+// updating the gateway's circuit-breaker state and metrics. In ModeNative it fetches through
+// fetchNativeAgainst so a gateway agreeing on bytes that don't hash to the
+// requested CID still fails, rather than being trusted outright; the
+// multi-gateway agreement in FetchQuorum then acts as defense-in-depth on
+// top of that per-gateway hash check, not instead of it.
+func (c *Client) fetchWithRetry(ctx context.Context, g *gatewayState, uri string, maxBytes int64) ([]byte, error) {
+	fetch, err := c.fetchOnceFunc(g.url, uri, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < retryMaxAttempts; attempt++ {
+		if attempt > 0 {
+			delay := retryBaseDelay * time.Duration(1<<uint(attempt-1))
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		start := time.Now()
+		body, err := fetch(ctx)
+		if err == nil {
+			g.recordSuccess(time.Since(start))
+			return body, nil
+		}
+		lastErr = err
+	}
+	g.recordFailure()
+	return nil, fmt.Errorf("gateway %s: %w", g.url, lastErr)
+}
+
+// fetchOnceFunc returns the single-attempt fetch for gateway, resolved once
+// up front: a plain HTTP GET in ModeGateway, or a CID-verifying fetch in
+// ModeNative.
+func (c *Client) fetchOnceFunc(gateway, uri string, maxBytes int64) (func(ctx context.Context) ([]byte, error), error) {
+	if c.mode == ModeNative {
+		return func(ctx context.Context) ([]byte, error) {
+			return c.fetchNativeAgainst(ctx, gateway, uri, maxBytes)
+		}, nil
+	}
+	url, err := c.resolveAgainst(gateway, uri)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context) ([]byte, error) {
+		return c.httpGet(ctx, url, maxBytes)
+	}, nil
+}
+
+// Fetcher is satisfied by anything that can retrieve raw bytes for a URI —
+// used to let callers (jsonval.Validator, policy.Load) fetch through a
+// quorum without depending on the concrete Client type.
+type Fetcher interface {
+	Fetch(ctx context.Context, uri string, maxBytes int64) ([]byte, error)
+}
+
+// QuorumFetcher adapts Client.FetchQuorum to the Fetcher interface with a
+// fixed (k, n), so it can be handed to jsonval.NewValidator or policy.Load.
+type QuorumFetcher struct {
+	c    *Client
+	k, n int
+}
+
+// Quorum returns a Fetcher that resolves every Fetch call via FetchQuorum(k, n).
+func (c *Client) Quorum(k, n int) *QuorumFetcher {
+	return &QuorumFetcher{c: c, k: k, n: n}
+}
+
+func (f *QuorumFetcher) Fetch(ctx context.Context, uri string, maxBytes int64) ([]byte, error) {
+	return f.c.FetchQuorum(ctx, uri, maxBytes, f.k, f.n)
+}
@@ -0,0 +1,67 @@
+package ipfs
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestGateway(t *testing.T, body string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestFetchQuorumAgreesAmongMajority(t *testing.T) {
+	a := newTestGateway(t, "metadata")
+	b := newTestGateway(t, "metadata")
+	c := newTestGateway(t, "tampered")
+
+	client := NewClient([]string{a.URL, b.URL, c.URL}, ModeGateway)
+	body, err := client.FetchQuorum(context.Background(), "ipfs://cid/metadata.json", 0, 2, 3)
+	if err != nil {
+		t.Fatalf("FetchQuorum: %v", err)
+	}
+	if string(body) != "metadata" {
+		t.Fatalf("got %q, want %q", body, "metadata")
+	}
+}
+
+func TestFetchQuorumFailsClosedOnATie(t *testing.T) {
+	honestA := newTestGateway(t, "honest")
+	honestB := newTestGateway(t, "honest")
+	maliciousA := newTestGateway(t, "malicious")
+	maliciousB := newTestGateway(t, "malicious")
+
+	client := NewClient([]string{honestA.URL, honestB.URL, maliciousA.URL, maliciousB.URL}, ModeGateway)
+	for i := 0; i < 20; i++ {
+		if _, err := client.FetchQuorum(context.Background(), "ipfs://cid/metadata.json", 0, 2, 4); err == nil {
+			t.Fatal("expected two equally-sized groups reaching k to fail closed rather than pick a winner")
+		}
+	}
+}
+
+func TestFetchQuorumFailsWithoutAgreement(t *testing.T) {
+	a := newTestGateway(t, "one")
+	b := newTestGateway(t, "two")
+
+	client := NewClient([]string{a.URL, b.URL}, ModeGateway)
+	if _, err := client.FetchQuorum(context.Background(), "ipfs://cid/metadata.json", 0, 2, 2); err == nil {
+		t.Fatal("expected no-quorum error when gateways disagree")
+	}
+}
+
+func TestCircuitBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	g := &gatewayState{url: "http://down.example"}
+	for i := 0; i < circuitBreakerThreshold; i++ {
+		g.recordFailure()
+	}
+	if g.available(time.Now()) {
+		t.Fatal("expected gateway to be unavailable after tripping the circuit breaker")
+	}
+}
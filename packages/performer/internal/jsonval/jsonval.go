@@ -16,16 +16,6 @@ func Parse(b []byte) (JSONMap, error) {
 	return m, nil
 }
 
-func PlaceholderValidate(oldM, newM JSONMap, schema JSONMap) bool {
-	// Default rule: every key present in old must exist in new
-	for k := range oldM {
-		if _, ok := newM[k]; !ok {
-			return false
-		}
-	}
-	return true
-}
-
 // MissingKeys returns keys present in oldM but absent in newM.
 func MissingKeys(oldM, newM JSONMap) []string {
 	var out []string
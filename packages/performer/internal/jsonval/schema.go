@@ -0,0 +1,447 @@
+package jsonval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fetcher retrieves raw bytes for a URI, mirroring ipfs.Client.Fetch. Defined
+// here so jsonval does not import the ipfs package; any type with this Fetch
+// method satisfies it, such as the worker's quorum fetcher or the tests'
+// in-memory fetchers.
+type Fetcher interface {
+	Fetch(ctx context.Context, uri string, maxBytes int64) ([]byte, error)
+}
+
+// SchemaViolation describes a single JSON Schema keyword failure.
+type SchemaViolation struct {
+	Path    string
+	Keyword string
+	Message string
+}
+
+func (v SchemaViolation) String() string {
+	return fmt.Sprintf("%s: %s (%s)", v.Path, v.Message, v.Keyword)
+}
+
+// compiledSchema is a schema document with every $ref eagerly resolved and
+// inlined, so validation never has to fetch or look anything up.
+type compiledSchema struct {
+	root map[string]interface{}
+}
+
+// Validator validates JSON documents against draft-07/2020-12 schemas,
+// resolving $ref (including ipfs:// remote refs) through a Fetcher and
+// caching compiled schemas by CID so repeated tasks don't re-fetch/re-parse.
+type Validator struct {
+	fetcher  Fetcher
+	maxFetch int64
+
+	mu    sync.Mutex
+	cache map[string]*compiledSchema
+}
+
+func NewValidator(fetcher Fetcher, maxFetch int64) *Validator {
+	return &Validator{
+		fetcher:  fetcher,
+		maxFetch: maxFetch,
+		cache:    make(map[string]*compiledSchema),
+	}
+}
+
+// Validate fetches and compiles the schema at schemaURI (or reuses a cached
+// compilation keyed by its CID) and validates instance against it.
+func (v *Validator) Validate(ctx context.Context, instance JSONMap, schemaURI string) ([]SchemaViolation, error) {
+	cs, err := v.compile(ctx, schemaURI)
+	if err != nil {
+		return nil, err
+	}
+	// validateValue type-switches on plain map[string]interface{}/[]interface{}
+	// (the shapes encoding/json produces), not the named JSONMap type, so the
+	// root instance must be converted rather than passed as-is.
+	return validateValue(map[string]interface{}(instance), cs.root, "$"), nil
+}
+
+func (v *Validator) compile(ctx context.Context, schemaURI string) (*compiledSchema, error) {
+	cid := cidOf(schemaURI)
+
+	v.mu.Lock()
+	if cs, ok := v.cache[cid]; ok {
+		v.mu.Unlock()
+		return cs, nil
+	}
+	v.mu.Unlock()
+
+	doc, err := v.fetchJSON(ctx, schemaURI)
+	if err != nil {
+		return nil, fmt.Errorf("fetch schema %s: %w", schemaURI, err)
+	}
+	resolved, err := v.resolveRefs(ctx, doc, schemaURI, map[string]bool{cid: true})
+	if err != nil {
+		return nil, err
+	}
+	cs := &compiledSchema{root: resolved}
+
+	v.mu.Lock()
+	v.cache[cid] = cs
+	v.mu.Unlock()
+	return cs, nil
+}
+
+func (v *Validator) fetchJSON(ctx context.Context, uri string) (map[string]interface{}, error) {
+	b, err := v.fetcher.Fetch(ctx, uri, v.maxFetch)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("invalid schema json: %w", err)
+	}
+	return m, nil
+}
+
+// resolveRefs walks schema, fetching and inlining every $ref. baseURI is the
+// ipfs:// URI the schema document itself was fetched from, used to resolve
+// fragment-only refs ("#/definitions/foo"). visited guards against cyclic
+// remote refs between schema documents.
+func (v *Validator) resolveRefs(ctx context.Context, node interface{}, baseURI string, visited map[string]bool) (map[string]interface{}, error) {
+	m, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("schema node is not an object")
+	}
+	if ref, ok := m["$ref"].(string); ok {
+		// Copy visited so it holds only the refs on the current path: sibling
+		// branches may reference the same target without it counting as a cycle.
+		pathVisited := make(map[string]bool, len(visited)+1)
+		for k := range visited {
+			pathVisited[k] = true
+		}
+		target, targetBase, err := v.loadRef(ctx, ref, baseURI, pathVisited)
+		if err != nil {
+			return nil, err
+		}
+		return v.resolveRefs(ctx, target, targetBase, pathVisited)
+	}
+
+	out := make(map[string]interface{}, len(m))
+	for k, val := range m {
+		out[k] = val
+	}
+	for _, kw := range []string{"properties", "patternProperties", "definitions", "$defs"} {
+		sub, ok := m[kw].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		resolvedSub := make(map[string]interface{}, len(sub))
+		for k, child := range sub {
+			rc, err := v.resolveRefs(ctx, child, baseURI, visited)
+			if err != nil {
+				return nil, err
+			}
+			resolvedSub[k] = rc
+		}
+		out[kw] = resolvedSub
+	}
+	for _, kw := range []string{"oneOf", "anyOf", "allOf", "items"} {
+		switch sub := m[kw].(type) {
+		case []interface{}:
+			resolvedList := make([]interface{}, len(sub))
+			for i, child := range sub {
+				rc, err := v.resolveRefs(ctx, child, baseURI, visited)
+				if err != nil {
+					return nil, err
+				}
+				resolvedList[i] = rc
+			}
+			out[kw] = resolvedList
+		case map[string]interface{}:
+			rc, err := v.resolveRefs(ctx, sub, baseURI, visited)
+			if err != nil {
+				return nil, err
+			}
+			out[kw] = rc
+		}
+	}
+	return out, nil
+}
+
+// loadRef resolves a single $ref value into its target node plus the base
+// URI that node's own refs should be resolved against.
+func (v *Validator) loadRef(ctx context.Context, ref, baseURI string, visited map[string]bool) (interface{}, string, error) {
+	if strings.HasPrefix(ref, "#/") {
+		localKey := baseURI + ref
+		if visited[localKey] {
+			return nil, "", fmt.Errorf("cyclic $ref detected for %s", ref)
+		}
+		visited[localKey] = true
+
+		doc, err := v.fetchJSON(ctx, baseURI)
+		if err != nil {
+			return nil, "", fmt.Errorf("resolve local ref %s: %w", ref, err)
+		}
+		target, err := jsonPointer(doc, ref[1:])
+		if err != nil {
+			return nil, "", fmt.Errorf("local ref %s: %w", ref, err)
+		}
+		return target, baseURI, nil
+	}
+	if !strings.HasPrefix(ref, "ipfs://") {
+		return nil, "", fmt.Errorf("unsupported $ref scheme: %s", ref)
+	}
+	refURI, fragment, _ := strings.Cut(ref, "#")
+	cid := cidOf(refURI)
+	if visited[cid] {
+		return nil, "", fmt.Errorf("cyclic $ref detected for cid %s", cid)
+	}
+	visited[cid] = true
+
+	doc, err := v.fetchJSON(ctx, refURI)
+	if err != nil {
+		return nil, "", fmt.Errorf("resolve remote ref %s: %w", ref, err)
+	}
+	if fragment == "" {
+		return doc, refURI, nil
+	}
+	target, err := jsonPointer(doc, fragment)
+	if err != nil {
+		return nil, "", fmt.Errorf("remote ref %s: %w", ref, err)
+	}
+	return target, refURI, nil
+}
+
+func jsonPointer(doc map[string]interface{}, pointer string) (interface{}, error) {
+	pointer = strings.TrimPrefix(pointer, "/")
+	var cur interface{} = doc
+	for _, tok := range strings.Split(pointer, "/") {
+		if tok == "" {
+			continue
+		}
+		tok = strings.ReplaceAll(tok, "~1", "/")
+		tok = strings.ReplaceAll(tok, "~0", "~")
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("cannot descend into %q", tok)
+		}
+		next, ok := m[tok]
+		if !ok {
+			return nil, fmt.Errorf("pointer segment %q not found", tok)
+		}
+		cur = next
+	}
+	return cur, nil
+}
+
+// cidOf returns the CID portion of an ipfs:// URI (the host up to the first
+// "/" or "#"), used as the schema cache key.
+func cidOf(uri string) string {
+	rest := strings.TrimPrefix(uri, "ipfs://")
+	if i := strings.IndexAny(rest, "/#"); i >= 0 {
+		rest = rest[:i]
+	}
+	return rest
+}
+
+func validateValue(instance interface{}, schema map[string]interface{}, path string) []SchemaViolation {
+	var errs []SchemaViolation
+
+	if t, ok := schema["type"]; ok {
+		if !matchesType(instance, t) {
+			errs = append(errs, SchemaViolation{Path: path, Keyword: "type", Message: fmt.Sprintf("expected type %v", t)})
+			return errs
+		}
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		if !inEnum(instance, enum) {
+			errs = append(errs, SchemaViolation{Path: path, Keyword: "enum", Message: "value not in enum"})
+		}
+	}
+	if format, ok := schema["format"].(string); ok {
+		if s, ok := instance.(string); ok {
+			if !matchesFormat(s, format) {
+				errs = append(errs, SchemaViolation{Path: path, Keyword: "format", Message: fmt.Sprintf("does not match format %q", format)})
+			}
+		}
+	}
+
+	if obj, ok := instance.(map[string]interface{}); ok {
+		errs = append(errs, validateObject(obj, schema, path)...)
+	}
+
+	for _, combinator := range []string{"oneOf", "anyOf"} {
+		subs, ok := schema[combinator].([]interface{})
+		if !ok {
+			continue
+		}
+		matched := 0
+		for _, s := range subs {
+			sub, ok := s.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if len(validateValue(instance, sub, path)) == 0 {
+				matched++
+			}
+		}
+		if combinator == "oneOf" && matched != 1 {
+			errs = append(errs, SchemaViolation{Path: path, Keyword: "oneOf", Message: fmt.Sprintf("matched %d subschemas, want exactly 1", matched)})
+		}
+		if combinator == "anyOf" && matched == 0 {
+			errs = append(errs, SchemaViolation{Path: path, Keyword: "anyOf", Message: "matched no subschemas"})
+		}
+	}
+	if subs, ok := schema["allOf"].([]interface{}); ok {
+		for _, s := range subs {
+			sub, ok := s.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			errs = append(errs, validateValue(instance, sub, path)...)
+		}
+	}
+
+	return errs
+}
+
+func validateObject(obj map[string]interface{}, schema map[string]interface{}, path string) []SchemaViolation {
+	var errs []SchemaViolation
+
+	if required, ok := schema["required"].([]interface{}); ok {
+		for _, r := range required {
+			key, ok := r.(string)
+			if !ok {
+				continue
+			}
+			if _, present := obj[key]; !present {
+				errs = append(errs, SchemaViolation{Path: path, Keyword: "required", Message: fmt.Sprintf("missing required property %q", key)})
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	matched := make(map[string]bool, len(obj))
+	for key, propSchema := range properties {
+		val, present := obj[key]
+		if !present {
+			continue
+		}
+		matched[key] = true
+		ps, ok := propSchema.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		errs = append(errs, validateValue(val, ps, path+"."+key)...)
+	}
+
+	patternProps, _ := schema["patternProperties"].(map[string]interface{})
+	compiled := make(map[string]*regexp.Regexp, len(patternProps))
+	for pattern, propSchema := range patternProps {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			continue
+		}
+		compiled[pattern] = re
+		ps, ok := propSchema.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for key, val := range obj {
+			if !re.MatchString(key) {
+				continue
+			}
+			matched[key] = true
+			errs = append(errs, validateValue(val, ps, path+"."+key)...)
+		}
+	}
+
+	if additional, ok := schema["additionalProperties"]; ok {
+		if allowed, isBool := additional.(bool); isBool && !allowed {
+			for key := range obj {
+				if !matched[key] {
+					errs = append(errs, SchemaViolation{Path: path + "." + key, Keyword: "additionalProperties", Message: fmt.Sprintf("unexpected property %q", key)})
+				}
+			}
+		} else if additionalSchema, ok := additional.(map[string]interface{}); ok {
+			for key, val := range obj {
+				if matched[key] {
+					continue
+				}
+				errs = append(errs, validateValue(val, additionalSchema, path+"."+key)...)
+			}
+		}
+	}
+
+	return errs
+}
+
+func matchesType(instance interface{}, t interface{}) bool {
+	switch tv := t.(type) {
+	case string:
+		return matchesSingleType(instance, tv)
+	case []interface{}:
+		for _, alt := range tv {
+			if s, ok := alt.(string); ok && matchesSingleType(instance, s) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
+
+func matchesSingleType(instance interface{}, t string) bool {
+	switch t {
+	case "object":
+		_, ok := instance.(map[string]interface{})
+		return ok
+	case "array":
+		_, ok := instance.([]interface{})
+		return ok
+	case "string":
+		_, ok := instance.(string)
+		return ok
+	case "number":
+		_, ok := instance.(float64)
+		return ok
+	case "integer":
+		f, ok := instance.(float64)
+		return ok && f == float64(int64(f))
+	case "boolean":
+		_, ok := instance.(bool)
+		return ok
+	case "null":
+		return instance == nil
+	default:
+		return true
+	}
+}
+
+func inEnum(instance interface{}, enum []interface{}) bool {
+	for _, v := range enum {
+		if fmt.Sprint(v) == fmt.Sprint(instance) {
+			return true
+		}
+	}
+	return false
+}
+
+var emailRe = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+
+func matchesFormat(s, format string) bool {
+	switch format {
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, s)
+		return err == nil
+	case "email":
+		return emailRe.MatchString(s)
+	case "uri":
+		return strings.Contains(s, ":")
+	default:
+		return true
+	}
+}
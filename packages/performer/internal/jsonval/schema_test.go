@@ -0,0 +1,267 @@
+package jsonval
+
+import (
+	"context"
+	"testing"
+)
+
+// mapFetcher resolves ipfs:// URIs against an in-memory map of raw bytes,
+// standing in for ipfs.Client in tests.
+type mapFetcher map[string][]byte
+
+func (f mapFetcher) Fetch(ctx context.Context, uri string, maxBytes int64) ([]byte, error) {
+	b, ok := f[uri]
+	if !ok {
+		return nil, errNotFound(uri)
+	}
+	return b, nil
+}
+
+type errNotFound string
+
+func (e errNotFound) Error() string { return "not found: " + string(e) }
+
+// countingFetcher wraps a mapFetcher and records how many times each URI was
+// fetched, so tests can assert the compiled-schema cache avoids re-fetching.
+type countingFetcher struct {
+	mapFetcher
+	calls map[string]int
+}
+
+func newCountingFetcher(f mapFetcher) *countingFetcher {
+	return &countingFetcher{mapFetcher: f, calls: make(map[string]int)}
+}
+
+func (f *countingFetcher) Fetch(ctx context.Context, uri string, maxBytes int64) ([]byte, error) {
+	f.calls[uri]++
+	return f.mapFetcher.Fetch(ctx, uri, maxBytes)
+}
+
+func TestValidateRootTypeAndRequired(t *testing.T) {
+	fetcher := mapFetcher{
+		"ipfs://schema": []byte(`{
+			"type": "object",
+			"required": ["status"],
+			"properties": {"status": {"type": "string"}}
+		}`),
+	}
+	v := NewValidator(fetcher, 1<<20)
+
+	instance, err := Parse([]byte(`{"status": "VALID"}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	violations, err := v.Validate(context.Background(), instance, "ipfs://schema")
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("expected a conforming root object to pass, got %v", violations)
+	}
+
+	missing, err := Parse([]byte(`{}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	violations, err = v.Validate(context.Background(), missing, "ipfs://schema")
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(violations) != 1 || violations[0].Keyword != "required" {
+		t.Fatalf("expected exactly one required violation, got %v", violations)
+	}
+}
+
+func TestValidateResolvesRemoteRef(t *testing.T) {
+	fetcher := mapFetcher{
+		"ipfs://schema": []byte(`{
+			"type": "object",
+			"properties": {"status": {"$ref": "ipfs://statusSchema"}}
+		}`),
+		"ipfs://statusSchema": []byte(`{"type": "string", "enum": ["VALID", "REVOKED"]}`),
+	}
+	v := NewValidator(fetcher, 1<<20)
+
+	instance, err := Parse([]byte(`{"status": "PENDING"}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	violations, err := v.Validate(context.Background(), instance, "ipfs://schema")
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(violations) != 1 || violations[0].Keyword != "enum" {
+		t.Fatalf("expected the remote $ref's enum constraint to apply, got %v", violations)
+	}
+}
+
+func TestValidateResolvesRemoteRefFragment(t *testing.T) {
+	fetcher := mapFetcher{
+		"ipfs://schema": []byte(`{
+			"type": "object",
+			"properties": {"status": {"$ref": "ipfs://defs#/definitions/status"}}
+		}`),
+		"ipfs://defs": []byte(`{
+			"definitions": {"status": {"type": "string", "enum": ["VALID"]}}
+		}`),
+	}
+	v := NewValidator(fetcher, 1<<20)
+
+	instance, err := Parse([]byte(`{"status": "VALID"}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	violations, err := v.Validate(context.Background(), instance, "ipfs://schema")
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("expected a conforming value to pass, got %v", violations)
+	}
+}
+
+func TestValidateCachesCompiledSchemaByCID(t *testing.T) {
+	fetcher := newCountingFetcher(mapFetcher{
+		"ipfs://schema": []byte(`{"type": "object", "required": ["status"]}`),
+	})
+	v := NewValidator(fetcher, 1<<20)
+
+	instance, err := Parse([]byte(`{"status": "VALID"}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := v.Validate(context.Background(), instance, "ipfs://schema"); err != nil {
+			t.Fatalf("validate %d: %v", i, err)
+		}
+	}
+	if got := fetcher.calls["ipfs://schema"]; got != 1 {
+		t.Fatalf("expected the fetcher to be called exactly once across repeated Validate calls, got %d", got)
+	}
+}
+
+func TestValidateDetectsCyclicLocalRef(t *testing.T) {
+	fetcher := mapFetcher{
+		"ipfs://schema": []byte(`{
+			"definitions": {
+				"a": {"$ref": "#/definitions/b"},
+				"b": {"$ref": "#/definitions/a"}
+			},
+			"$ref": "#/definitions/a"
+		}`),
+	}
+	v := NewValidator(fetcher, 1<<20)
+
+	instance, err := Parse([]byte(`{}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if _, err := v.Validate(context.Background(), instance, "ipfs://schema"); err == nil {
+		t.Fatal("expected cyclic local $ref to be rejected, got nil error")
+	}
+}
+
+func TestValidateDetectsCyclicRemoteRef(t *testing.T) {
+	fetcher := mapFetcher{
+		"ipfs://a": []byte(`{"$ref": "ipfs://b"}`),
+		"ipfs://b": []byte(`{"$ref": "ipfs://a"}`),
+	}
+	v := NewValidator(fetcher, 1<<20)
+
+	instance, err := Parse([]byte(`{}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if _, err := v.Validate(context.Background(), instance, "ipfs://a"); err == nil {
+		t.Fatal("expected cyclic remote $ref to be rejected, got nil error")
+	}
+}
+
+func TestValidateCombinators(t *testing.T) {
+	fetcher := mapFetcher{
+		"ipfs://schema": []byte(`{
+			"type": "object",
+			"properties": {
+				"contact": {
+					"oneOf": [
+						{"type": "string"},
+						{"type": "number"}
+					],
+					"anyOf": [
+						{"type": "string"},
+						{"type": "number"}
+					],
+					"allOf": [
+						{"type": "string"},
+						{"format": "email"}
+					]
+				}
+			}
+		}`),
+	}
+	v := NewValidator(fetcher, 1<<20)
+
+	ok, err := Parse([]byte(`{"contact": "alice@example.com"}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	violations, err := v.Validate(context.Background(), ok, "ipfs://schema")
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("expected a matching string to pass oneOf/anyOf/allOf, got %v", violations)
+	}
+
+	neither, err := Parse([]byte(`{"contact": true}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	violations, err = v.Validate(context.Background(), neither, "ipfs://schema")
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	var kws []string
+	for _, viol := range violations {
+		kws = append(kws, viol.Keyword)
+	}
+	if len(violations) != 3 {
+		t.Fatalf("expected a bool to fail oneOf, anyOf (matches neither) and allOf's string branch, got %v", kws)
+	}
+}
+
+func TestValidatePatternAndAdditionalProperties(t *testing.T) {
+	fetcher := mapFetcher{
+		"ipfs://schema": []byte(`{
+			"type": "object",
+			"properties": {"status": {"type": "string"}},
+			"patternProperties": {"^x-": {"type": "number"}},
+			"additionalProperties": false
+		}`),
+	}
+	v := NewValidator(fetcher, 1<<20)
+
+	allowed, err := Parse([]byte(`{"status": "VALID", "x-priority": 1}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	violations, err := v.Validate(context.Background(), allowed, "ipfs://schema")
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(violations) != 0 {
+		t.Fatalf("expected a declared + pattern-matched property to pass, got %v", violations)
+	}
+
+	rejected, err := Parse([]byte(`{"status": "VALID", "extra": "nope"}`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	violations, err = v.Validate(context.Background(), rejected, "ipfs://schema")
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if len(violations) != 1 || violations[0].Keyword != "additionalProperties" {
+		t.Fatalf("expected an undeclared property to be rejected, got %v", violations)
+	}
+}
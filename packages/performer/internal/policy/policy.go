@@ -0,0 +1,118 @@
+// Package policy evaluates declarative semantic-diff rules between the old
+// and new metadata JSON for a patent token, beyond the baseline "no keys
+// dropped" check: monotonic status transitions, immutable fields, append-only
+// arrays and numeric bounds. Rules are loaded from a rules document rather
+// than hard-coded so operators can tighten or relax policy without a
+// redeploy.
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/liquid-ip/performer/internal/jsonval"
+)
+
+// Violation describes a single rule failure found while diffing oldM and newM.
+type Violation struct {
+	Rule    string
+	Field   string
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s[%s]: %s", v.Rule, v.Field, v.Message)
+}
+
+// Rule evaluates one semantic-diff check against an (oldM, newM) pair.
+type Rule interface {
+	Evaluate(oldM, newM jsonval.JSONMap) []Violation
+}
+
+// RuleSet is an ordered collection of rules evaluated independently; their
+// violations are aggregated by the caller.
+type RuleSet struct {
+	Rules []Rule
+}
+
+func (rs *RuleSet) Evaluate(oldM, newM jsonval.JSONMap) []Violation {
+	var out []Violation
+	for _, r := range rs.Rules {
+		out = append(out, r.Evaluate(oldM, newM)...)
+	}
+	return out
+}
+
+// Fetcher retrieves raw bytes for a URI, mirroring ipfs.Client.Fetch.
+type Fetcher interface {
+	Fetch(ctx context.Context, uri string, maxBytes int64) ([]byte, error)
+}
+
+// Load fetches a rules document from uri and builds a RuleSet from it.
+func Load(ctx context.Context, fetcher Fetcher, uri string, maxBytes int64) (*RuleSet, error) {
+	b, err := fetcher.Fetch(ctx, uri, maxBytes)
+	if err != nil {
+		return nil, fmt.Errorf("fetch rules: %w", err)
+	}
+	return Parse(b)
+}
+
+type rulesDoc struct {
+	Rules []ruleDoc `json:"rules"`
+}
+
+type ruleDoc struct {
+	Type        string       `json:"type"`
+	Field       string       `json:"field"`
+	Transitions []transition `json:"transitions"`
+	Min         *float64     `json:"min"`
+	Max         *float64     `json:"max"`
+}
+
+type transition struct {
+	From             string `json:"from"`
+	To               string `json:"to"`
+	RequiresEvidence bool   `json:"requiresEvidence"`
+}
+
+// Parse builds a RuleSet from a JSON rules document.
+func Parse(b []byte) (*RuleSet, error) {
+	var doc rulesDoc
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("invalid rules document: %w", err)
+	}
+	rs := &RuleSet{}
+	for _, rd := range doc.Rules {
+		rule, err := build(rd)
+		if err != nil {
+			return nil, err
+		}
+		rs.Rules = append(rs.Rules, rule)
+	}
+	return rs, nil
+}
+
+func build(rd ruleDoc) (Rule, error) {
+	switch rd.Type {
+	case "monotonic":
+		allowed := make(map[[2]string]bool, len(rd.Transitions))
+		requiresEvidence := make(map[[2]string]bool, len(rd.Transitions))
+		for _, t := range rd.Transitions {
+			key := [2]string{t.From, t.To}
+			allowed[key] = true
+			if t.RequiresEvidence {
+				requiresEvidence[key] = true
+			}
+		}
+		return &MonotonicRule{Field: rd.Field, Allowed: allowed, RequiresEvidence: requiresEvidence}, nil
+	case "immutable":
+		return &ImmutableRule{Field: rd.Field}, nil
+	case "appendOnly":
+		return &AppendOnlyRule{Field: rd.Field}, nil
+	case "numericBounds":
+		return &NumericBoundsRule{Field: rd.Field, Min: rd.Min, Max: rd.Max}, nil
+	default:
+		return nil, fmt.Errorf("unknown rule type: %q", rd.Type)
+	}
+}
@@ -0,0 +1,122 @@
+package policy
+
+import (
+	"fmt"
+
+	"github.com/liquid-ip/performer/internal/jsonval"
+)
+
+// MonotonicRule allows a field to move only along a declared set of
+// transitions (e.g. status VALID -> INVALID), rejecting any move not in
+// Allowed. A transition also listed in RequiresEvidence (e.g. a move back to
+// a prior value) is additionally rejected unless newM carries a non-empty
+// top-level "evidence" field.
+type MonotonicRule struct {
+	Field            string
+	Allowed          map[[2]string]bool
+	RequiresEvidence map[[2]string]bool
+}
+
+func (r *MonotonicRule) Evaluate(oldM, newM jsonval.JSONMap) []Violation {
+	oldV, oldOK := oldM[r.Field].(string)
+	newV, newOK := newM[r.Field].(string)
+	if !oldOK || !newOK || oldV == newV {
+		return nil
+	}
+	transition := [2]string{oldV, newV}
+	if !r.Allowed[transition] {
+		return []Violation{{
+			Rule:    "monotonic",
+			Field:   r.Field,
+			Message: fmt.Sprintf("transition %s -> %s is not permitted", oldV, newV),
+		}}
+	}
+	if r.RequiresEvidence[transition] && !hasEvidence(newM) {
+		return []Violation{{
+			Rule:    "monotonic",
+			Field:   r.Field,
+			Message: fmt.Sprintf("transition %s -> %s requires evidence", oldV, newV),
+		}}
+	}
+	return nil
+}
+
+// hasEvidence reports whether m carries a non-empty top-level "evidence"
+// field, the proof a gated transition must supply.
+func hasEvidence(m jsonval.JSONMap) bool {
+	s, ok := m["evidence"].(string)
+	return ok && s != ""
+}
+
+// ImmutableRule rejects any change to Field once set.
+type ImmutableRule struct {
+	Field string
+}
+
+func (r *ImmutableRule) Evaluate(oldM, newM jsonval.JSONMap) []Violation {
+	oldV, oldOK := oldM[r.Field]
+	newV, newOK := newM[r.Field]
+	if !oldOK || !newOK {
+		return nil
+	}
+	if fmt.Sprint(oldV) == fmt.Sprint(newV) {
+		return nil
+	}
+	return []Violation{{
+		Rule:    "immutable",
+		Field:   r.Field,
+		Message: fmt.Sprintf("value changed from %v to %v", oldV, newV),
+	}}
+}
+
+// AppendOnlyRule requires that every element of the old array at Field still
+// appear, in order, as a prefix of the new array.
+type AppendOnlyRule struct {
+	Field string
+}
+
+func (r *AppendOnlyRule) Evaluate(oldM, newM jsonval.JSONMap) []Violation {
+	oldArr, oldOK := oldM[r.Field].([]interface{})
+	newArr, newOK := newM[r.Field].([]interface{})
+	if !oldOK {
+		return nil
+	}
+	if !newOK {
+		return []Violation{{Rule: "appendOnly", Field: r.Field, Message: "array removed"}}
+	}
+	if len(newArr) < len(oldArr) {
+		return []Violation{{Rule: "appendOnly", Field: r.Field, Message: "array shrank"}}
+	}
+	for i, oldEl := range oldArr {
+		if fmt.Sprint(oldEl) != fmt.Sprint(newArr[i]) {
+			return []Violation{{
+				Rule:    "appendOnly",
+				Field:   r.Field,
+				Message: fmt.Sprintf("element %d changed from %v to %v", i, oldEl, newArr[i]),
+			}}
+		}
+	}
+	return nil
+}
+
+// NumericBoundsRule requires the new value of Field, if present and
+// numeric, to fall within [Min, Max] (either bound may be nil/unbounded).
+type NumericBoundsRule struct {
+	Field string
+	Min   *float64
+	Max   *float64
+}
+
+func (r *NumericBoundsRule) Evaluate(oldM, newM jsonval.JSONMap) []Violation {
+	v, ok := newM[r.Field].(float64)
+	if !ok {
+		return nil
+	}
+	if r.Min != nil && v < *r.Min {
+		return []Violation{{Rule: "numericBounds", Field: r.Field, Message: fmt.Sprintf("%v below minimum %v", v, *r.Min)}}
+	}
+	if r.Max != nil && v > *r.Max {
+		return []Violation{{Rule: "numericBounds", Field: r.Field, Message: fmt.Sprintf("%v above maximum %v", v, *r.Max)}}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/liquid-ip/performer/internal/jsonval"
+)
+
+func TestMonotonicRule(t *testing.T) {
+	r := &MonotonicRule{
+		Field:   "status",
+		Allowed: map[[2]string]bool{{"VALID", "INVALID"}: true},
+	}
+	if v := r.Evaluate(jsonval.JSONMap{"status": "VALID"}, jsonval.JSONMap{"status": "INVALID"}); len(v) != 0 {
+		t.Fatalf("expected allowed transition to pass, got %v", v)
+	}
+	if v := r.Evaluate(jsonval.JSONMap{"status": "INVALID"}, jsonval.JSONMap{"status": "VALID"}); len(v) == 0 {
+		t.Fatal("expected reverse transition to be rejected")
+	}
+}
+
+func TestMonotonicRuleRequiresEvidence(t *testing.T) {
+	r := &MonotonicRule{
+		Field:            "status",
+		Allowed:          map[[2]string]bool{{"INVALID", "VALID"}: true},
+		RequiresEvidence: map[[2]string]bool{{"INVALID", "VALID"}: true},
+	}
+	oldM := jsonval.JSONMap{"status": "INVALID"}
+	if v := r.Evaluate(oldM, jsonval.JSONMap{"status": "VALID"}); len(v) == 0 {
+		t.Fatal("expected gated transition without evidence to be rejected")
+	}
+	if v := r.Evaluate(oldM, jsonval.JSONMap{"status": "VALID", "evidence": "re-examined under appeal #42"}); len(v) != 0 {
+		t.Fatalf("expected gated transition with evidence to pass, got %v", v)
+	}
+}
+
+func TestImmutableRule(t *testing.T) {
+	r := &ImmutableRule{Field: "inventor"}
+	if v := r.Evaluate(jsonval.JSONMap{"inventor": "Ada"}, jsonval.JSONMap{"inventor": "Ada"}); len(v) != 0 {
+		t.Fatalf("expected unchanged value to pass, got %v", v)
+	}
+	if v := r.Evaluate(jsonval.JSONMap{"inventor": "Ada"}, jsonval.JSONMap{"inventor": "Bob"}); len(v) == 0 {
+		t.Fatal("expected changed value to be rejected")
+	}
+}
+
+func TestAppendOnlyRule(t *testing.T) {
+	r := &AppendOnlyRule{Field: "claims"}
+	old := jsonval.JSONMap{"claims": []interface{}{"c1", "c2"}}
+	if v := r.Evaluate(old, jsonval.JSONMap{"claims": []interface{}{"c1", "c2", "c3"}}); len(v) != 0 {
+		t.Fatalf("expected append to pass, got %v", v)
+	}
+	if v := r.Evaluate(old, jsonval.JSONMap{"claims": []interface{}{"c1"}}); len(v) == 0 {
+		t.Fatal("expected shrinking array to be rejected")
+	}
+	if v := r.Evaluate(old, jsonval.JSONMap{"claims": []interface{}{"c1", "changed"}}); len(v) == 0 {
+		t.Fatal("expected mutated prefix to be rejected")
+	}
+}
+
+func TestNumericBoundsRule(t *testing.T) {
+	min, max := 0.0, 100.0
+	r := &NumericBoundsRule{Field: "confidence", Min: &min, Max: &max}
+	if v := r.Evaluate(nil, jsonval.JSONMap{"confidence": 50.0}); len(v) != 0 {
+		t.Fatalf("expected in-bounds value to pass, got %v", v)
+	}
+	if v := r.Evaluate(nil, jsonval.JSONMap{"confidence": 150.0}); len(v) == 0 {
+		t.Fatal("expected out-of-bounds value to be rejected")
+	}
+}
+
+func TestParseAndEvaluate(t *testing.T) {
+	doc := []byte(`{
+		"rules": [
+			{"type": "immutable", "field": "inventor"},
+			{"type": "monotonic", "field": "status", "transitions": [{"from": "VALID", "to": "INVALID"}]}
+		]
+	}`)
+	rs, err := Parse(doc)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	oldM := jsonval.JSONMap{"inventor": "Ada", "status": "VALID"}
+	newM := jsonval.JSONMap{"inventor": "Bob", "status": "INVALID"}
+	violations := rs.Evaluate(oldM, newM)
+	if len(violations) != 1 {
+		t.Fatalf("expected exactly 1 violation (immutable), got %v", violations)
+	}
+}
@@ -0,0 +1,68 @@
+package watcher
+
+import (
+	"sync"
+	"time"
+)
+
+// taskDeadline is a settable, re-armable deadline for a single in-flight
+// task. It mirrors the pipeDeadline helper net.Pipe uses internally: a
+// channel that closes once the deadline passes, so anything selecting on
+// wait() wakes up, with set() able to move the deadline (or clear it) without
+// leaking the previous timer. Used to cancel in-flight IPFS fetches cleanly
+// when the aggregator's task window expires.
+type taskDeadline struct {
+	mu     sync.Mutex
+	timer  *time.Timer
+	cancel chan struct{} // closed when the deadline passes
+}
+
+func newTaskDeadline() *taskDeadline {
+	return &taskDeadline{cancel: make(chan struct{})}
+}
+
+func isClosed(ch chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+// set arms the deadline for t. A zero t clears it (no automatic cancel). A
+// past t cancels immediately.
+func (d *taskDeadline) set(t time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.timer != nil && !d.timer.Stop() {
+		<-d.cancel // wait for the in-flight callback to finish closing cancel
+	}
+	d.timer = nil
+
+	closed := isClosed(d.cancel)
+	if t.IsZero() {
+		if closed {
+			d.cancel = make(chan struct{})
+		}
+		return
+	}
+	if dur := time.Until(t); dur > 0 {
+		if closed {
+			d.cancel = make(chan struct{})
+		}
+		d.timer = time.AfterFunc(dur, func() { close(d.cancel) })
+		return
+	}
+	if !closed {
+		close(d.cancel)
+	}
+}
+
+// wait returns the channel that closes once the deadline has passed.
+func (d *taskDeadline) wait() chan struct{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.cancel
+}
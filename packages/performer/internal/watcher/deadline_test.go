@@ -0,0 +1,48 @@
+package watcher
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTaskDeadlineClosesWaitAfterDeadlinePasses(t *testing.T) {
+	d := newTaskDeadline()
+	d.set(time.Now().Add(20 * time.Millisecond))
+
+	select {
+	case <-d.wait():
+	case <-time.After(time.Second):
+		t.Fatal("expected wait() to close once the deadline passed")
+	}
+}
+
+func TestTaskDeadlineSetClearsPreviousTimer(t *testing.T) {
+	d := newTaskDeadline()
+	d.set(time.Now().Add(5 * time.Millisecond))
+	// Re-arming further out must cancel the short timer rather than racing it.
+	d.set(time.Now().Add(200 * time.Millisecond))
+
+	select {
+	case <-d.wait():
+		t.Fatal("wait() closed before the re-armed deadline passed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	select {
+	case <-d.wait():
+	case <-time.After(time.Second):
+		t.Fatal("expected wait() to close once the re-armed deadline passed")
+	}
+}
+
+func TestTaskDeadlineSetZeroClearsIt(t *testing.T) {
+	d := newTaskDeadline()
+	d.set(time.Now().Add(10 * time.Millisecond))
+	d.set(time.Time{})
+
+	select {
+	case <-d.wait():
+		t.Fatal("expected wait() to stay open after clearing the deadline")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,167 @@
+// Package watcher implements the log-driven "push" half of the performer:
+// instead of waiting for Hourglass to route a TaskRequest over RPC, it
+// subscribes to VerificationRequested events on the patent registry and
+// feeds synthetic TaskRequests straight into the shared VerifierWorker.
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+
+	"github.com/liquid-ip/performer/internal/abiutil"
+	"github.com/liquid-ip/performer/internal/config"
+	appworker "github.com/liquid-ip/performer/internal/worker"
+)
+
+// verificationRequestedSig is the event signature hash for
+// VerificationRequested(uint256 tokenId, string newUri). The event's data
+// is ABI-encoded (uint256, string), identical to the VerifyPayload the RPC
+// path decodes, so it can be handed to VerifierWorker unchanged.
+var verificationRequestedSig = crypto.Keccak256Hash([]byte("VerificationRequested(uint256,string)"))
+
+// Watcher subscribes to VerificationRequested logs on a registry contract
+// and dispatches each one to a VerifierWorker, with a cancellable
+// per-task deadline. It registers reconnect callbacks on cfg so that a hot
+// reload changing RpcURL or the registry address (ENS or hex) restarts the
+// subscription instead of silently continuing to watch a stale
+// endpoint/contract — the same rebuild-on-change contract worker.VerifierWorker
+// already gives pull mode.
+type Watcher struct {
+	logger      *zap.Logger
+	worker      *appworker.VerifierWorker
+	taskTimeout time.Duration
+
+	rpcPtr  atomic.Pointer[ethclient.Client]
+	restart chan struct{}
+}
+
+func NewWatcher(l *zap.Logger, cfg *config.Store, w *appworker.VerifierWorker, taskTimeout time.Duration) (*Watcher, error) {
+	rpc, err := ethclient.Dial(cfg.Get().RpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("watcher: dial rpc: %w", err)
+	}
+	wt := &Watcher{
+		logger:      l,
+		worker:      w,
+		taskTimeout: taskTimeout,
+		restart:     make(chan struct{}, 1),
+	}
+	wt.rpcPtr.Store(rpc)
+
+	cfg.OnRpcURLChange(func(c *config.Config) {
+		rpc, err := ethclient.Dial(c.RpcURL)
+		if err != nil {
+			l.Sugar().Warnw("watcher: redial rpc after reload failed", "rpcUrl", c.RpcURL, "error", err)
+			return
+		}
+		wt.rpcPtr.Store(rpc)
+		wt.signalRestart()
+	})
+	cfg.OnRegistryChange(func(*config.Config) {
+		wt.signalRestart()
+	})
+
+	return wt, nil
+}
+
+func (w *Watcher) signalRestart() {
+	select {
+	case w.restart <- struct{}{}:
+	default:
+	}
+}
+
+// Run subscribes to the registry's VerificationRequested event and
+// dispatches tasks until ctx is cancelled or the subscription errors,
+// restarting the subscription whenever RpcURL or the registry address
+// changes underneath it.
+func (w *Watcher) Run(ctx context.Context) error {
+	for {
+		runCtx, cancel := context.WithCancel(ctx)
+		errCh := make(chan error, 1)
+		go func() { errCh <- w.runSubscription(runCtx) }()
+
+		select {
+		case <-w.restart:
+			cancel()
+			<-errCh
+			w.logger.Sugar().Infow("watcher.restarting", "registry", w.worker.RegistryAddress().Hex())
+		case err := <-errCh:
+			cancel()
+			return err
+		}
+	}
+}
+
+// runSubscription subscribes to the current registry address over the
+// current rpc client and dispatches tasks until ctx is cancelled or the
+// subscription errors.
+func (w *Watcher) runSubscription(ctx context.Context) error {
+	rpc := w.rpcPtr.Load()
+	registry := w.worker.RegistryAddress()
+
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{registry},
+		Topics:    [][]common.Hash{{verificationRequestedSig}},
+	}
+	logs := make(chan types.Log, 16)
+	sub, err := rpc.SubscribeFilterLogs(ctx, query, logs)
+	if err != nil {
+		return fmt.Errorf("watcher: subscribe filter logs: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	w.logger.Sugar().Infow("watcher.started", "registry", registry.Hex())
+	for {
+		select {
+		case err := <-sub.Err():
+			return fmt.Errorf("watcher: subscription error: %w", err)
+		case vLog := <-logs:
+			go w.handleLog(ctx, vLog)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// handleLog decodes a single VerificationRequested log and routes it
+// through the shared worker, enforcing a per-task deadline so a slow IPFS
+// fetch can't outlive the aggregator's task window.
+func (w *Watcher) handleLog(ctx context.Context, vLog types.Log) {
+	tokenId, newUri, err := abiutil.DecodeVerifyPayload(vLog.Data)
+	if err != nil {
+		w.logger.Sugar().Warnw("watcher: decode event data failed", "txHash", vLog.TxHash.Hex(), "error", err)
+		return
+	}
+
+	dl := newTaskDeadline()
+	dl.set(time.Now().Add(w.taskTimeout))
+
+	taskCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-dl.wait():
+			cancel()
+		case <-taskCtx.Done():
+		}
+	}()
+
+	taskId := vLog.TxHash.Bytes()
+	resp, err := w.worker.HandleTaskWithContext(taskCtx, &performer.TaskRequest{TaskId: taskId, Payload: vLog.Data})
+	if err != nil {
+		w.logger.Sugar().Warnw("watcher: handle task failed", "tokenId", tokenId.String(), "newUri", newUri, "error", err)
+		return
+	}
+	w.logger.Sugar().Infow("watcher: task handled", "tokenId", tokenId.String(), "newUri", newUri, "result_len", len(resp.Result))
+}
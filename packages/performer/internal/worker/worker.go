@@ -2,7 +2,11 @@ package worker
 
 import (
 	"context"
+	"fmt"
 	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/protocol-apis/gen/protos/eigenlayer/hourglass/v1/performer"
@@ -14,23 +18,147 @@ import (
 	ethcli "github.com/liquid-ip/performer/internal/eth"
 	ipfscli "github.com/liquid-ip/performer/internal/ipfs"
 	"github.com/liquid-ip/performer/internal/jsonval"
+	"github.com/liquid-ip/performer/internal/policy"
 )
 
+const maxFetch = 5 << 20 // 5MB cap
+
 type VerifierWorker struct {
-	logger   *zap.Logger
-	cfg      *config.Config
-	ipfs     *ipfscli.Client
-	eth      *ethcli.Client
-	maxFetch int64
+	logger *zap.Logger
+	cfg    *config.Store
+
+	ipfsPtr     atomic.Pointer[ipfscli.Client]
+	ethPtr      atomic.Pointer[ethcli.Client]
+	registryPtr atomic.Pointer[common.Address]
+	schemaPtr   atomic.Pointer[jsonval.Validator]
+
+	policyMu    sync.Mutex
+	policyRules *policy.RuleSet
+}
+
+// NewVerifierWorker builds a worker backed by cfgStore. It registers
+// reconnect callbacks so that when a hot reload changes RpcURL, the registry
+// address or the primary IPFS gateway, eth.Client/ipfs.Client (and anything
+// built on top of them) are rebuilt rather than silently going stale.
+func NewVerifierWorker(l *zap.Logger, cfgStore *config.Store) (*VerifierWorker, error) {
+	w := &VerifierWorker{logger: l, cfg: cfgStore}
+
+	cfg := cfgStore.Get()
+	if err := w.rebuildEth(cfg); err != nil {
+		return nil, err
+	}
+	w.rebuildIpfs(cfg)
+
+	cfgStore.OnRpcURLChange(func(c *config.Config) {
+		if err := w.rebuildEth(c); err != nil {
+			w.logger.Sugar().Warnw("rebuild eth client after reload failed", "rpcUrl", c.RpcURL, "error", err)
+		}
+	})
+	cfgStore.OnRegistryChange(func(c *config.Config) {
+		if err := w.resolveRegistry(c, w.ethPtr.Load()); err != nil {
+			w.logger.Sugar().Warnw("resolve registry address after reload failed", "registry", c.Erc721PatentRegistryAddress, "error", err)
+		}
+	})
+	cfgStore.OnGatewayChange(func(c *config.Config) {
+		w.rebuildIpfs(c)
+	})
+
+	return w, nil
 }
 
-func NewVerifierWorker(l *zap.Logger, cfg *config.Config) (*VerifierWorker, error) {
-	ip := ipfscli.NewClient(cfg.IpfsGateway)
+// rebuildEth reconnects the eth.Client against cfg.RpcURL and re-resolves
+// the registry address against it.
+func (w *VerifierWorker) rebuildEth(cfg *config.Config) error {
 	eth, err := ethcli.NewClient(cfg.RpcURL)
+	if err != nil {
+		return err
+	}
+	w.ethPtr.Store(eth)
+	return w.resolveRegistry(cfg, eth)
+}
+
+// resolveRegistry resolves cfg.Erc721PatentRegistryAddress (an ENS name or a
+// hex address) into registryPtr using eth, without mutating cfg — Config is
+// shared by every reader via Store.Get() and must stay immutable once
+// published. A reload that leaves Erc721PatentRegistryAddress unchanged keeps
+// the address already in registryPtr; a reload that changes it fires
+// OnRegistryChange (or OnRpcURLChange, via rebuildEth), which re-runs this
+// resolution rather than leaving the stale address in place.
+func (w *VerifierWorker) resolveRegistry(cfg *config.Config, eth *ethcli.Client) error {
+	if !isENSName(cfg.Erc721PatentRegistryAddress) {
+		addr := common.HexToAddress(cfg.Erc721PatentRegistryAddress)
+		w.registryPtr.Store(&addr)
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	addr, err := eth.ResolveName(ctx, cfg.Erc721PatentRegistryAddress)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("resolve erc721 registry ens name %q: %w", cfg.Erc721PatentRegistryAddress, err)
+	}
+	w.logger.Sugar().Infow("ens.resolved", "name", cfg.Erc721PatentRegistryAddress, "address", addr.Hex())
+	w.registryPtr.Store(&addr)
+	return nil
+}
+
+// RegistryAddress returns the currently resolved ERC-721 registry address,
+// i.e. the ENS name in cfg.Erc721PatentRegistryAddress resolved against eth
+// (or the address itself if it wasn't an ENS name). Callers that need the
+// registry address outside of task handling (e.g. the watcher subscribing to
+// registry logs) must use this instead of re-parsing the raw config value.
+func (w *VerifierWorker) RegistryAddress() common.Address {
+	return *w.registryPtr.Load()
+}
+
+func (w *VerifierWorker) rebuildIpfs(cfg *config.Config) {
+	ip := ipfscli.NewClient(cfg.IpfsGateways, ipfscli.Mode(cfg.IpfsMode))
+	w.ipfsPtr.Store(ip)
+	w.schemaPtr.Store(jsonval.NewValidator(&liveQuorumFetcher{w}, maxFetch))
+
+	// the cached rules were parsed with a Fetcher over the old gateways;
+	// drop them so the next policy check re-fetches through the new client.
+	w.policyMu.Lock()
+	w.policyRules = nil
+	w.policyMu.Unlock()
+}
+
+// liveQuorumFetcher adapts ipfs.Client.Quorum into a jsonval.Fetcher that
+// re-reads QuorumK/QuorumN from the live Config on every Fetch, rather than
+// baking them in at construction time — matching loadPolicy, which passes
+// the live cfg.QuorumK/QuorumN to ipfs.Quorum whenever it fetches the rules
+// document. Without
+// this, a hot reload that changes only the quorum (no gateway change, so
+// OnGatewayChange never fires and the schema validator is never rebuilt)
+// would leave schema validation silently using the stale k/n indefinitely.
+type liveQuorumFetcher struct {
+	w *VerifierWorker
+}
+
+func (f *liveQuorumFetcher) Fetch(ctx context.Context, uri string, maxBytes int64) ([]byte, error) {
+	cfg := f.w.cfg.Get()
+	return f.w.ipfsPtr.Load().Quorum(cfg.QuorumK, cfg.QuorumN).Fetch(ctx, uri, maxBytes)
+}
+
+// loadPolicy fetches and parses the rules document at cfg.PolicyURI on first
+// use and caches it, since the document rarely changes between tasks.
+func (w *VerifierWorker) loadPolicy(ctx context.Context, cfg *config.Config, ipfs *ipfscli.Client) (*policy.RuleSet, error) {
+	w.policyMu.Lock()
+	defer w.policyMu.Unlock()
+	if w.policyRules != nil {
+		return w.policyRules, nil
+	}
+	rs, err := policy.Load(ctx, ipfs.Quorum(cfg.QuorumK, cfg.QuorumN), cfg.PolicyURI, maxFetch)
 	if err != nil {
 		return nil, err
 	}
-	return &VerifierWorker{logger: l, cfg: cfg, ipfs: ip, eth: eth, maxFetch: 5 << 20}, nil // 5MB cap
+	w.policyRules = rs
+	return rs, nil
+}
+
+// isENSName reports whether s looks like an ENS name rather than a hex
+// address or an already-resolved URI.
+func isENSName(s string) bool {
+	return !strings.HasPrefix(s, "0x") && !strings.Contains(s, "://") && strings.Contains(s, ".")
 }
 
 func (w *VerifierWorker) ValidateTask(t *performer.TaskRequest) error {
@@ -38,9 +166,25 @@ func (w *VerifierWorker) ValidateTask(t *performer.TaskRequest) error {
 	return err
 }
 
+// HandleTask implements the RPC ("pull") path required by the performer
+// server framework, which has no notion of a caller-supplied context: it
+// derives one bounded by cfg.TaskTimeout and delegates to
+// HandleTaskWithContext.
 func (w *VerifierWorker) HandleTask(t *performer.TaskRequest) (*performer.TaskResponse, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), w.cfg.Get().TaskTimeout)
 	defer cancel()
+	return w.HandleTaskWithContext(ctx, t)
+}
+
+// HandleTaskWithContext validates t against ctx, which the caller is
+// responsible for bounding/cancelling. watcher.handleLog passes its own
+// deadline-armed context here so in-flight IPFS fetches are cancelled
+// cleanly when the aggregator's task window expires.
+func (w *VerifierWorker) HandleTaskWithContext(ctx context.Context, t *performer.TaskRequest) (*performer.TaskResponse, error) {
+	cfg := w.cfg.Get()
+	ipfs := w.ipfsPtr.Load()
+	eth := w.ethPtr.Load()
+	schema := w.schemaPtr.Load()
 
 	w.logger.Sugar().Infow("handle.start", "taskId_len", len(t.TaskId), "payload_len", len(t.Payload))
 	id, newUri, err := abiutil.DecodeVerifyPayload(t.Payload)
@@ -50,22 +194,36 @@ func (w *VerifierWorker) HandleTask(t *performer.TaskRequest) (*performer.TaskRe
 	}
 	w.logger.Sugar().Infow("payload.decoded", "tokenId", id.String(), "newUri", newUri)
 
-	// fetch old tokenURI from ERC721
-	oldURI, err := w.eth.TokenURI(ctx, common.HexToAddress(w.cfg.Erc721PatentRegistryAddress), id)
+	if isENSName(newUri) {
+		ensName := newUri
+		resolved, err := eth.ResolveContenthash(ctx, ensName)
+		if err != nil {
+			w.logger.Sugar().Warnw("ens contenthash resolve failed", "name", ensName, "error", err)
+			return w.result(t.TaskId, id, false, 0)
+		}
+		w.logger.Sugar().Infow("ens.contenthash.resolved", "name", ensName, "uri", resolved)
+		newUri = resolved
+	}
+
+	// fetch old tokenURI from ERC721, using the already-resolved registry
+	// address rather than re-parsing cfg.Erc721PatentRegistryAddress, which
+	// may still be an unresolved ENS name.
+	registry := *w.registryPtr.Load()
+	oldURI, err := eth.TokenURI(ctx, registry, id)
 	if err != nil {
-		w.logger.Sugar().Warnw("tokenURI failed", "contract", w.cfg.Erc721PatentRegistryAddress, "tokenId", id.String(), "error", err)
+		w.logger.Sugar().Warnw("tokenURI failed", "contract", registry.Hex(), "tokenId", id.String(), "error", err)
 		return w.result(t.TaskId, id, false, 0)
 	}
 	w.logger.Sugar().Infow("tokenURI.ok", "oldUri", oldURI)
-	oldB, err := w.ipfs.Fetch(ctx, oldURI, w.maxFetch)
+	oldB, err := ipfs.FetchQuorum(ctx, oldURI, maxFetch, cfg.QuorumK, cfg.QuorumN)
 	if err != nil {
 		w.logger.Sugar().Warnw("ipfs old fetch failed", "uri", oldURI, "error", err)
-		return w.result(t.TaskId, id, false, 0)
+		return w.result(t.TaskId, id, false, abiutil.StatusNoQuorum)
 	}
-	newB, err := w.ipfs.Fetch(ctx, newUri, w.maxFetch)
+	newB, err := ipfs.FetchQuorum(ctx, newUri, maxFetch, cfg.QuorumK, cfg.QuorumN)
 	if err != nil {
 		w.logger.Sugar().Warnw("ipfs new fetch failed", "uri", newUri, "error", err)
-		return w.result(t.TaskId, id, false, 0)
+		return w.result(t.TaskId, id, false, abiutil.StatusNoQuorum)
 	}
 
 	// parse JSONs
@@ -80,28 +238,38 @@ func (w *VerifierWorker) HandleTask(t *performer.TaskRequest) (*performer.TaskRe
 		return w.result(t.TaskId, id, false, 0)
 	}
 
-	// schema optional
-	var schemaM jsonval.JSONMap = nil
-	if w.cfg.SchemaURI != "" {
-		w.logger.Sugar().Infow("schema.fetch", "schemaUri", w.cfg.SchemaURI)
-		schemaB, err := w.ipfs.Fetch(ctx, w.cfg.SchemaURI, w.maxFetch)
+	if missing := jsonval.MissingKeys(oldM, newM); len(missing) > 0 {
+		w.logger.Sugar().Infow("validation.failed", "missingKeys", missing)
+		return w.result(t.TaskId, id, false, abiutil.StatusMissingKeys)
+	}
+
+	if cfg.SchemaURI != "" {
+		w.logger.Sugar().Infow("schema.validate", "schemaUri", cfg.SchemaURI)
+		violations, err := schema.Validate(ctx, newM, cfg.SchemaURI)
 		if err != nil {
-			w.logger.Sugar().Warnw("schema fetch failed", "error", err)
-			return w.result(t.TaskId, id, false, 0)
+			w.logger.Sugar().Warnw("schema fetch/compile failed", "schemaUri", cfg.SchemaURI, "error", err)
+			return w.result(t.TaskId, id, false, abiutil.StatusFetchFailed)
 		}
-		schemaM, err = jsonval.Parse(schemaB)
-		if err != nil {
-			w.logger.Sugar().Warnw("schema parse failed", "error", err)
-			return w.result(t.TaskId, id, false, 0)
+		if len(violations) > 0 {
+			w.logger.Sugar().Infow("schema.invalid", "violations", violations)
+			return w.result(t.TaskId, id, false, abiutil.StatusSchemaInvalid)
 		}
-		w.logger.Sugar().Infow("schema.loaded")
+		w.logger.Sugar().Infow("schema.ok")
 	}
 
-	if !jsonval.PlaceholderValidate(oldM, newM, schemaM) {
-		missing := jsonval.MissingKeys(oldM, newM)
-		w.logger.Sugar().Infow("validation.failed", "missingKeys", missing)
-		return w.result(t.TaskId, id, false, 0)
+	if cfg.PolicyURI != "" {
+		rules, err := w.loadPolicy(ctx, cfg, ipfs)
+		if err != nil {
+			w.logger.Sugar().Warnw("policy load failed", "policyUri", cfg.PolicyURI, "error", err)
+			return w.result(t.TaskId, id, false, abiutil.StatusFetchFailed)
+		}
+		if violations := rules.Evaluate(oldM, newM); len(violations) > 0 {
+			w.logger.Sugar().Infow("policy.violated", "violations", violations)
+			return w.result(t.TaskId, id, false, abiutil.StatusPolicyViolation)
+		}
+		w.logger.Sugar().Infow("policy.ok")
 	}
+
 	status, err := jsonval.StatusFromJSON(newM)
 	if err != nil {
 		w.logger.Sugar().Warnw("status parse failed", "error", err)